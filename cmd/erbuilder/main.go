@@ -10,6 +10,7 @@ import (
 	"github.com/eujoy/erbuilder/internal/app/service"
 	"github.com/eujoy/erbuilder/internal/config"
 	"github.com/eujoy/erbuilder/internal/domain"
+	"github.com/eujoy/erbuilder/internal/pkg/loader"
 	"github.com/eujoy/erbuilder/internal/pkg/survey"
 	"github.com/eujoy/erbuilder/internal/pkg/util"
 	"github.com/eujoy/erbuilder/internal/pkg/writer"
@@ -32,18 +33,31 @@ func Main() {
 			Usage:   "Generate the .er file based on the provided structures.",
 			Flags: []cli.Flag{
 				options.GetCommonFields(),
+				options.GetDescriptions(),
+				options.GetDialect(),
 				options.GetDirectoryFlag(),
 				options.GetExtraTablesDefinition(),
 				options.GetExtraTablesSurvey(),
 				options.GetFileList(),
+				options.GetForeignKeyRules(),
+				options.GetForeignKeyAllowList(),
+				options.GetForeignKeyDenyList(),
 				options.GetIDField(),
+				options.GetJunctionTables(),
+				options.GetMigrations(),
+				options.GetMigrationVersion(),
 				options.GetOutputFilename(),
 				options.GetOutputPath(),
+				options.GetPackages(),
+				options.GetSQLFiles(),
+				options.GetSQLite(),
+				options.GetSyntheticIDField(),
 				options.GetTag(),
 				options.GetTitle(),
 				options.GetColumnNameCase(),
 				options.GetTableNameCase(),
 				options.GetTableNamePlural(),
+				options.GetTypeMappingFile(),
 			},
 			Action: func(c *cli.Context) error {
 				err := options.Validate()
@@ -51,14 +65,137 @@ func Main() {
 					panic(err)
 				}
 
+				loader := loader.New()
 				survey := survey.New()
 				util := util.New()
 				writer := writer.New(util, options.OutputPath, options.OutputFilename)
 
-				srv := service.New(options, survey, util, writer)
+				srv := service.New(options, loader, survey, util, writer)
 				return srv.Generate()
 			},
 		},
+		{
+			Name:    "explain",
+			Aliases: []string{"x"},
+			Usage:   "Explain why each struct, column and reference of the .er file has been produced, as text or json.",
+			Flags: []cli.Flag{
+				options.GetCommonFields(),
+				options.GetDialect(),
+				options.GetDirectoryFlag(),
+				options.GetExplainFormat(),
+				options.GetFileList(),
+				options.GetForeignKeyRules(),
+				options.GetForeignKeyAllowList(),
+				options.GetForeignKeyDenyList(),
+				options.GetIDField(),
+				options.GetJunctionTables(),
+				options.GetPackages(),
+				options.GetSyntheticIDField(),
+				options.GetTag(),
+				options.GetColumnNameCase(),
+				options.GetTableNameCase(),
+				options.GetTableNamePlural(),
+				options.GetTypeMappingFile(),
+			},
+			Action: func(c *cli.Context) error {
+				err := options.Validate()
+				if err != nil {
+					return err
+				}
+
+				loader := loader.New()
+				util := util.New()
+
+				srv := service.New(options, loader, nil, util, nil)
+				explanation, err := srv.Explain()
+				if err != nil {
+					return err
+				}
+				fmt.Println(explanation)
+
+				return nil
+			},
+		},
+		{
+			Name:    "introspect",
+			Aliases: []string{"i"},
+			Usage:   "Generate the .er file based on the tables of a database, read from its information schema.",
+			Flags: []cli.Flag{
+				options.GetDescriptions(),
+				options.GetDriver(),
+				options.GetDSN(),
+				options.GetJunctionTables(),
+				options.GetOutputFilename(),
+				options.GetOutputPath(),
+				options.GetSchema(),
+				options.GetTitle(),
+			},
+			Action: func(c *cli.Context) error {
+				err := options.ValidateIntrospection()
+				if err != nil {
+					return err
+				}
+
+				util := util.New()
+				writer := writer.New(util, options.OutputPath, options.OutputFilename)
+
+				srv := service.New(options, nil, nil, util, writer)
+				return srv.Introspect()
+			},
+		},
+		{
+			Name:    "drift",
+			Aliases: []string{"d"},
+			Usage:   "Report the differences between the tables of the structures and the tables of the sql files, migrations or database, failing on any.",
+			Flags: []cli.Flag{
+				options.GetCommonFields(),
+				options.GetDialect(),
+				options.GetDirectoryFlag(),
+				options.GetDriver(),
+				options.GetDSN(),
+				options.GetExplainFormat(),
+				options.GetFileList(),
+				options.GetForeignKeyRules(),
+				options.GetForeignKeyAllowList(),
+				options.GetForeignKeyDenyList(),
+				options.GetIDField(),
+				options.GetJunctionTables(),
+				options.GetMigrations(),
+				options.GetMigrationVersion(),
+				options.GetPackages(),
+				options.GetSchema(),
+				options.GetSQLFiles(),
+				options.GetSQLite(),
+				options.GetSyntheticIDField(),
+				options.GetTag(),
+				options.GetColumnNameCase(),
+				options.GetTableNameCase(),
+				options.GetTableNamePlural(),
+				options.GetTypeMappingFile(),
+			},
+			Action: func(c *cli.Context) error {
+				err := options.ValidateDrift()
+				if err != nil {
+					return err
+				}
+
+				loader := loader.New()
+				util := util.New()
+
+				srv := service.New(options, loader, nil, util, nil)
+				report, hasDrift, err := srv.Drift()
+				if err != nil {
+					return err
+				}
+				fmt.Println(report)
+
+				if hasDrift {
+					return cli.Exit("", 1)
+				}
+
+				return nil
+			},
+		},
 		{
 			Name:    "build",
 			Aliases: []string{"b"},
@@ -76,7 +213,7 @@ func Main() {
 
 				survey := survey.New()
 				util := util.New()
-				srv := service.New(options, survey, util, nil)
+				srv := service.New(options, nil, survey, util, nil)
 				extraDefinition, err := srv.Build()
 				if err != nil {
 					return err
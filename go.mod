@@ -1,18 +1,70 @@
 module github.com/eujoy/erbuilder
 
-go 1.14
+go 1.25.0
 
 require (
 	github.com/AlecAivazis/survey/v2 v2.0.8
-	github.com/Netflix/go-expect v0.0.0-20180615182759-c93bf25de8e8 // indirect
 	github.com/gertd/go-pluralize v0.1.7
+	github.com/go-sql-driver/mysql v1.8.1
 	github.com/iancoleman/strcase v0.0.0-20191112232945-16388991a334
-	github.com/kyoh86/richgo v0.3.3 // indirect
-	github.com/schrej/godacov v0.0.0-20191017093636-565cfab00b51 // indirect
-	github.com/stretchr/objx v0.3.0 // indirect
+	github.com/lib/pq v1.10.9
 	github.com/stretchr/testify v1.6.1
 	github.com/udhos/equalfile v0.3.0
 	github.com/urfave/cli/v2 v2.2.0
+	golang.org/x/tools v0.47.0
 	gopkg.in/go-playground/colors.v1 v1.2.0
+	modernc.org/sqlite v1.38.2
+)
+
+require (
+	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/BurntSushi/toml v0.3.1 // indirect
+	github.com/Netflix/go-expect v0.0.0-20180615182759-c93bf25de8e8 // indirect
+	github.com/cpuguy83/go-md2man/v2 v2.0.0-20190314233015-f79a8a8ca69d // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/deadcheat/goblet v1.3.1 // indirect
+	github.com/deadcheat/gonch v0.0.0-20180528124129-c2ff7a019863 // indirect
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/golang/mock v1.1.1 // indirect
+	github.com/google/go-cmp v0.6.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/hinshun/vt10x v0.0.0-20180616224451-1954e6464174 // indirect
+	github.com/inconshreveable/mousetrap v1.0.0 // indirect
+	github.com/kballard/go-shellquote v0.0.0-20180428030007-95032a82bc51 // indirect
+	github.com/kr/pty v1.1.4 // indirect
+	github.com/kyoh86/richgo v0.3.3 // indirect
+	github.com/kyoh86/xdg v0.0.0-20171007020617-d28e4c5d7b81 // indirect
+	github.com/mattn/go-colorable v0.1.2 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mgutz/ansi v0.0.0-20170206155736-9520e82c474b // indirect
+	github.com/morikuni/aec v0.0.0-20170113033406-39771216ff4c // indirect
+	github.com/ncruces/go-strftime v0.1.9 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
+	github.com/russross/blackfriday/v2 v2.0.1 // indirect
+	github.com/schrej/godacov v0.0.0-20191017093636-565cfab00b51 // indirect
+	github.com/shurcooL/sanitized_anchor_name v1.0.0 // indirect
+	github.com/spf13/cobra v0.0.3 // indirect
+	github.com/spf13/pflag v1.0.3 // indirect
+	github.com/stretchr/objx v0.3.0 // indirect
+	github.com/urfave/cli v1.20.0 // indirect
+	github.com/wacul/ptr v0.0.0-20170209030335-91632201dfc8 // indirect
+	github.com/yuin/goldmark v1.4.13 // indirect
+	golang.org/x/crypto v0.53.0 // indirect
+	golang.org/x/exp v0.0.0-20250620022241-b7579e27df2b // indirect
+	golang.org/x/mod v0.37.0 // indirect
+	golang.org/x/net v0.56.0 // indirect
+	golang.org/x/sync v0.21.0 // indirect
+	golang.org/x/sys v0.46.0 // indirect
+	golang.org/x/telemetry v0.0.0-20260625142307-59b4966ccb57 // indirect
+	golang.org/x/term v0.44.0 // indirect
+	golang.org/x/text v0.38.0 // indirect
+	golang.org/x/xerrors v0.0.0-20190717185122-a985d3407aa7 // indirect
+	gopkg.in/check.v1 v0.0.0-20161208181325-20d25e280405 // indirect
+	gopkg.in/yaml.v2 v2.2.2 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200615113413-eeeca48fe776 // indirect
+	modernc.org/libc v1.66.3 // indirect
+	modernc.org/mathutil v1.7.1 // indirect
+	modernc.org/memory v1.11.0 // indirect
 )
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+	"github.com/eujoy/erbuilder/internal/pkg/tag"
+)
+
+const (
+	associationOne  = "one"
+	associationMany = "many"
+
+	ruleAssociation = "association"
+)
+
+// association describes an association field of a model (e.g. `Orders []Order`), relating the table of the model to
+// the table of the associated model.
+type association struct {
+	structKey  string
+	tableName  string
+	dialect    tag.Dialect
+	field      structField
+	definition tag.Column
+	model      string
+}
+
+// collectAssociations records the association fields of a table (e.g. `Addresses []Address` or `Profile *Profile`),
+// to find the references that they describe and as evidence of the cardinality of the references from the associated
+// tables.
+func (s *Service) collectAssociations(dialect tag.Dialect, structKey, tableName string, fields []structField) {
+	for _, field := range fields {
+		model, isAssociation := s.getReferencedModel(field)
+		if !isAssociation {
+			continue
+		}
+
+		var definition tag.Column
+		if value, found := reflect.StructTag(field.tag).Lookup(dialect.Key()); found {
+			definition = dialect.ParseColumn(value)
+		}
+
+		kind := associationOne
+		if isSliceField(field) {
+			kind = associationMany
+		}
+		s.associations[getAssociationKey(tableName, s.getTableName(model))] = kind
+
+		s.associationList = append(s.associationList, association{
+			structKey:  structKey,
+			tableName:  tableName,
+			dialect:    dialect,
+			field:      field,
+			definition: definition,
+			model:      model,
+		})
+	}
+}
+
+// isSliceField checks whether the type of a field is a slice or an array (e.g. `[]Address` or `[]*Address`).
+func isSliceField(field structField) bool {
+	for _, dataType := range []string{field.declaredType, field.dataType} {
+		if strings.HasPrefix(strings.TrimLeft(dataType, "*"), "[") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getAssociationReferences returns the references described by the association fields of the models, marking the
+// respective columns as foreign keys. The join tables of the many to many associations are added to the diagram,
+// unless already defined by a model.
+//
+// The references of the associations declaring their foreign key explicitly (e.g. `gorm:"foreignKey:BuyerID"`) on the
+// model itself have already been resolved as declared ones.
+func (s *Service) getAssociationReferences(diagram *domain.Diagram) []domain.Reference {
+	var referenceList []domain.Reference
+	found := map[string]bool{}
+	appendReference := func(reference domain.Reference) {
+		// both sides of an association to the same model (e.g. `Manager *Employee` and `Reports []Employee`) describe
+		// the same reference.
+		if !found[getReferenceKey(reference)] {
+			found[getReferenceKey(reference)] = true
+			referenceList = append(referenceList, reference)
+		}
+	}
+
+	for _, assoc := range s.associationList {
+		targetTable := s.getTableName(assoc.model)
+
+		switch assoc.definition.Relation {
+		case tag.RelationManyToMany:
+			for _, reference := range s.getJoinTableReferences(diagram, assoc, targetTable) {
+				appendReference(reference)
+			}
+		case tag.RelationBelongsTo:
+			if reference, isFound := s.getBelongsToReference(diagram, assoc, targetTable); isFound {
+				appendReference(reference)
+			}
+		case tag.RelationHasOne, tag.RelationHasMany:
+			if reference, isFound := s.getHasReference(diagram, assoc, targetTable); isFound {
+				appendReference(reference)
+			}
+		case "":
+			// without the kind of the relation, the association belongs to the associated model if the model has the
+			// respective column, otherwise the associated model has a column referring to the model.
+			if reference, isFound := s.getBelongsToReference(diagram, assoc, targetTable); isFound {
+				appendReference(reference)
+			} else if reference, isFound := s.getHasReference(diagram, assoc, targetTable); isFound {
+				appendReference(reference)
+			}
+		}
+	}
+
+	return referenceList
+}
+
+// getBelongsToReference returns the reference of a column of the model to the associated model (e.g. `creator_id` for
+// `Creator User`), if the column exists.
+func (s *Service) getBelongsToReference(diagram *domain.Diagram, assoc association, targetTable string) (domain.Reference, bool) {
+	if assoc.definition.ForeignKey != "" {
+		return domain.Reference{}, false
+	}
+
+	foreignKey := assoc.field.columnPrefix + s.util.GetCaseOfString(assoc.field.name+"ID", s.options.ColumnNameCase)
+
+	return s.getColumnReference(diagram, assoc.tableName, foreignKey, targetTable, "")
+}
+
+// getHasReference returns the reference of a column of the associated model to the model (e.g. `user_id` for
+// `Orders []Order` of `User`), if the column exists.
+//
+// The columns are declared by gorm as `foreignKey` (on the associated model) and `references` (on the model), while
+// by bun as `join:references=foreignKey`, otherwise the foreign key is named after the model.
+func (s *Service) getHasReference(diagram *domain.Diagram, assoc association, targetTable string) (domain.Reference, bool) {
+	foreignKey, references := assoc.definition.ForeignKey, assoc.definition.References
+	if assoc.dialect.Name() == "bun" {
+		foreignKey, references = references, foreignKey
+	}
+
+	if foreignKey == "" {
+		foreignKey = getStructName(assoc.structKey) + "ID"
+	}
+
+	if references != "" {
+		references = s.util.GetCaseOfString(references, s.options.ColumnNameCase)
+	}
+
+	return s.getColumnReference(diagram, targetTable, s.util.GetCaseOfString(foreignKey, s.options.ColumnNameCase), assoc.tableName, references)
+}
+
+// getColumnReference marks a column as foreign key and returns its reference to the provided table, if the column
+// exists and does not refer to any other table already.
+func (s *Service) getColumnReference(diagram *domain.Diagram, fromTable, fromColumn, toTable, toColumn string) (domain.Reference, bool) {
+	for idxTb := range diagram.TableList {
+		if diagram.TableList[idxTb].Name != fromTable {
+			continue
+		}
+
+		for idxCol := range diagram.TableList[idxTb].ColumnList {
+			column := &diagram.TableList[idxTb].ColumnList[idxCol]
+			if column.Name != fromColumn || column.ReferencedTable != "" || column.IsExtraField {
+				continue
+			}
+
+			column.IsForeignKey = true
+			return domain.Reference{
+				FromTableName:   fromTable,
+				FromTableColumn: fromColumn,
+				ToTableName:     toTable,
+				ToTableColumn:   toColumn,
+				Rule:            ruleAssociation,
+			}, true
+		}
+	}
+
+	return domain.Reference{}, false
+}
+
+// getJoinTableReferences returns the references of the join table of a many to many association to the tables of both
+// models, adding the join table to the diagram if not defined by a model (e.g. `user_roles` with `user_id` and
+// `role_id` for `Roles []Role` of `User`).
+func (s *Service) getJoinTableReferences(diagram *domain.Diagram, assoc association, targetTable string) []domain.Reference {
+	joinTable := assoc.definition.JoinTable
+	if joinTable == "" {
+		return []domain.Reference{}
+	}
+
+	for _, table := range diagram.TableList {
+		if table.Name == joinTable {
+			return []domain.Reference{}
+		}
+	}
+
+	foreignKey := assoc.definition.JoinForeignKey
+	if foreignKey == "" {
+		foreignKey = getStructName(assoc.structKey) + "ID"
+	}
+	foreignKey = s.util.GetCaseOfString(foreignKey, s.options.ColumnNameCase)
+
+	references := assoc.definition.JoinReferences
+	if references == "" {
+		references = getStructName(assoc.model) + "ID"
+	}
+	references = s.util.GetCaseOfString(references, s.options.ColumnNameCase)
+
+	// the associations of a model to itself (e.g. `Friends []User`) are named after the field instead.
+	if references == foreignKey {
+		references = s.util.GetCaseOfString(s.util.GetValueCount(false, assoc.field.name)+"ID", s.options.ColumnNameCase)
+	}
+
+	diagram.TableList = append(diagram.TableList, domain.Table{
+		Name: joinTable,
+		ColumnList: []domain.Column{
+			{Name: foreignKey, Type: getPrimaryKeyType(diagram, assoc.tableName), IsPrimaryKey: true, IsForeignKey: true},
+			{Name: references, Type: getPrimaryKeyType(diagram, targetTable), IsPrimaryKey: true, IsForeignKey: true},
+		},
+	})
+
+	return []domain.Reference{
+		{FromTableName: joinTable, FromTableColumn: foreignKey, ToTableName: assoc.tableName, Rule: ruleAssociation},
+		{FromTableName: joinTable, FromTableColumn: references, ToTableName: targetTable, Rule: ruleAssociation},
+	}
+}
+
+// getPrimaryKeyType returns the type of the primary key of a table, defaulting to integer.
+func getPrimaryKeyType(diagram *domain.Diagram, tableName string) string {
+	for _, table := range diagram.TableList {
+		if table.Name != tableName {
+			continue
+		}
+
+		for _, column := range table.ColumnList {
+			if column.IsPrimaryKey {
+				return column.Type
+			}
+		}
+	}
+
+	return columnTypeInteger
+}
+
+// getStructName returns the name of a struct out of its package qualified name (e.g. `User` for `models.User`).
+func getStructName(structKey string) string {
+	return structKey[strings.LastIndex(structKey, ".")+1:]
+}
+
+// getReferenceKey returns the key of a reference by the table and the column it refers from.
+func getReferenceKey(reference domain.Reference) string {
+	return fmt.Sprintf("%v.%v", reference.FromTableName, reference.FromTableColumn)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// sizedDataTypes describes the database data types accepting a length (e.g. `varchar(100)`).
+var sizedDataTypes = map[string]bool{
+	"varchar": true, "char": true, "nvarchar": true, "nchar": true, "character varying": true, "character": true,
+	"varbinary": true, "binary": true, "bit": true, "varbit": true,
+}
+
+// unlimitedTextDataTypes describes the database data types of the strings without a length (e.g. `text` in postgres),
+// which are sized as `varchar` instead.
+var unlimitedTextDataTypes = map[string]bool{"text": true, "tinytext": true, "mediumtext": true, "longtext": true}
+
+// loadDataTypeMapping reads the user provided mapping of code data types to database data types
+// (e.g. `{"uuid.UUID": "char(36)"}`), which takes precedence over the default mapping.
+func loadDataTypeMapping(filename string) (map[string]string, error) {
+	mapping := map[string]string{}
+	if filename == "" {
+		return mapping, nil
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return mapping, err
+	}
+
+	err = json.Unmarshal(content, &mapping)
+	if err != nil {
+		return map[string]string{}, err
+	}
+
+	return mapping, nil
+}
+
+// getColumnType returns the database data type of a field, whether the column may be null and the code data type
+// that it has been mapped from.
+//
+// The type is looked up as declared first (e.g. `time.Duration`) and then as resolved to its underlying type
+// (e.g. `int64`), so that both the commonly used types and the named types based on basic ones are mapped, according
+// to the database dialect in use. The nullability follows the type that the data type has been found for.
+func (s *Service) getColumnType(field structField) (string, bool, string) {
+	for _, dataType := range []string{field.declaredType, field.dataType} {
+		if dataType == "" {
+			continue
+		}
+
+		unwrapped, isNullable := s.util.ResolveNullableDataType(dataType)
+		if dbDataType, found := s.dataTypeMapping[unwrapped]; found {
+			return dbDataType, isNullable, unwrapped
+		}
+
+		if dbDataType, found := s.util.LookupDBDataType(unwrapped, s.options.Dialect); found {
+			return dbDataType, isNullable, unwrapped
+		}
+	}
+
+	unwrapped, isNullable := s.util.ResolveNullableDataType(field.dataType)
+	dbDataType, _ := s.util.LookupDBDataType(unwrapped, s.options.Dialect)
+	return dbDataType, isNullable, unwrapped
+}
+
+// getSizedDataType applies the size declared by a tag (e.g. `gorm:"size:100"`) to a database data type. The strings of
+// unlimited length become `varchar` of the size, while the data types that do not accept a length (e.g. `bigint`) or
+// already have one (e.g. `char(36)`) are kept as they are.
+func getSizedDataType(dataType, size string) string {
+	if size == "" || strings.Contains(dataType, "(") {
+		return dataType
+	}
+
+	sizedDataType := strings.ToLower(dataType)
+	if unlimitedTextDataTypes[sizedDataType] {
+		sizedDataType = "varchar"
+	} else if !sizedDataTypes[sizedDataType] {
+		return dataType
+	}
+
+	// the data types spelled in upper case (e.g. in sqlite) are kept in upper case.
+	if dataType == strings.ToUpper(dataType) {
+		sizedDataType = strings.ToUpper(sizedDataType)
+	}
+
+	return fmt.Sprintf("%v(%v)", sizedDataType, size)
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+)
+
+const (
+	driftTableMissingFromModels      = "table_missing_from_models"
+	driftTableMissingFromSchema      = "table_missing_from_schema"
+	driftColumnMissingFromModels     = "column_missing_from_models"
+	driftColumnMissingFromSchema     = "column_missing_from_schema"
+	driftTypeMismatch                = "type_mismatch"
+	driftForeignKeyMissingFromModels = "foreign_key_missing_from_models"
+)
+
+// dataTypeArguments matches the lengths, precisions and scales of the data types (e.g. `(255)` or `(10, 2)`).
+var dataTypeArguments = regexp.MustCompile(`\s*\([^)]*\)`)
+
+// dataTypeSynonyms describes the data types that are the same, as named by the different databases.
+var dataTypeSynonyms = map[string]string{
+	"int":                         "integer",
+	"int4":                        "integer",
+	"serial":                      "integer",
+	"serial4":                     "integer",
+	"int8":                        "bigint",
+	"bigserial":                   "bigint",
+	"serial8":                     "bigint",
+	"int2":                        "smallint",
+	"smallserial":                 "smallint",
+	"serial2":                     "smallint",
+	"bool":                        "boolean",
+	"float4":                      "real",
+	"float8":                      "double precision",
+	"double":                      "double precision",
+	"decimal":                     "numeric",
+	"character varying":           "varchar",
+	"character":                   "char",
+	"bpchar":                      "char",
+	"timestamp with time zone":    "timestamptz",
+	"timestamp without time zone": "timestamp",
+	"time with time zone":         "timetz",
+	"time without time zone":      "time",
+}
+
+// Drift performs the action to compare the tables of the provided structs against the tables of the provided schema
+// (sql files, migrations or database), returning the differences found formatted either as text or as json, and
+// whether there are any.
+func (s *Service) Drift() (string, bool, error) {
+	modelDiagram, err := s.getStructDiagram()
+	if err != nil {
+		return "", false, err
+	}
+
+	schemaDiagram, err := s.getDriftSchemaDiagram()
+	if err != nil {
+		return "", false, err
+	}
+
+	drift := getDrift(modelDiagram, schemaDiagram)
+	hasDrift := len(drift.Differences) > 0
+
+	if s.options.ExplainFormat == explainFormatJSON {
+		content, err := json.MarshalIndent(drift, "", "  ")
+		if err != nil {
+			return "", false, err
+		}
+
+		return string(content), hasDrift, nil
+	}
+
+	return formatDrift(drift), hasDrift, nil
+}
+
+// getDriftSchemaDiagram returns the diagram of the schema that the structs are compared against, read from the provided
+// database or replayed from the provided migrations and sql files.
+func (s *Service) getDriftSchemaDiagram() (domain.Diagram, error) {
+	if s.options.DSN != "" {
+		return s.getDatabaseDiagram()
+	}
+
+	if s.options.SQLite != "" {
+		return s.getSQLiteDiagram()
+	}
+
+	return s.getSchemaDiagram()
+}
+
+// getDrift returns the tables and the columns missing from either side, the columns whose data types differ and the
+// foreign keys of the schema that are not reflected by any reference of the models. The tables and the columns are
+// matched by their names case insensitively.
+func getDrift(modelDiagram, schemaDiagram domain.Diagram) domain.Drift {
+	drift := domain.Drift{Differences: []domain.Difference{}}
+
+	modelTables := indexTablesByName(modelDiagram.TableList)
+	for _, schemaTable := range schemaDiagram.TableList {
+		if _, found := modelTables[strings.ToLower(schemaTable.Name)]; !found {
+			drift.Differences = append(drift.Differences, domain.Difference{
+				Kind:  driftTableMissingFromModels,
+				Table: schemaTable.Name,
+			})
+		}
+	}
+
+	schemaTables := indexTablesByName(schemaDiagram.TableList)
+	for _, modelTable := range modelDiagram.TableList {
+		schemaTable, found := schemaTables[strings.ToLower(modelTable.Name)]
+		if !found {
+			drift.Differences = append(drift.Differences, domain.Difference{
+				Kind:   driftTableMissingFromSchema,
+				Table:  modelTable.Name,
+				Source: getTableSource(modelTable),
+			})
+			continue
+		}
+
+		drift.Differences = append(drift.Differences, getColumnDifferences(modelTable, schemaTable)...)
+	}
+
+	modelReferences := map[string]bool{}
+	for _, reference := range modelDiagram.ReferenceList {
+		modelReferences[getDriftReferenceKey(reference)] = true
+	}
+
+	for _, reference := range schemaDiagram.ReferenceList {
+		if modelReferences[getDriftReferenceKey(reference)] {
+			continue
+		}
+
+		drift.Differences = append(drift.Differences, domain.Difference{
+			Kind:      driftForeignKeyMissingFromModels,
+			Table:     reference.FromTableName,
+			Column:    strings.Join(reference.GetFromTableColumns(), ","),
+			Reference: fmt.Sprintf("%v.%v", reference.ToTableName, reference.ToTableColumn),
+		})
+	}
+
+	return drift
+}
+
+// getColumnDifferences returns the columns of a table missing from either side and the columns whose data types
+// differ, in the order of the columns of the models followed by the ones missing from them.
+func getColumnDifferences(modelTable, schemaTable domain.Table) []domain.Difference {
+	var differences []domain.Difference
+
+	schemaColumns := map[string]domain.Column{}
+	for _, column := range schemaTable.ColumnList {
+		schemaColumns[strings.ToLower(column.Name)] = column
+	}
+
+	modelColumns := map[string]bool{}
+	for _, modelColumn := range modelTable.ColumnList {
+		modelColumns[strings.ToLower(modelColumn.Name)] = true
+
+		schemaColumn, found := schemaColumns[strings.ToLower(modelColumn.Name)]
+		if !found {
+			differences = append(differences, domain.Difference{
+				Kind:      driftColumnMissingFromSchema,
+				Table:     modelTable.Name,
+				Column:    modelColumn.Name,
+				ModelType: modelColumn.Type,
+				Source:    getColumnSource(modelColumn),
+			})
+			continue
+		}
+
+		// the data types unknown to the models (described as `~`) and the ones of the common fields, declared by their
+		// name only, are not compared.
+		if modelColumn.Type == "~" || modelColumn.Type == "" || isSameDataType(modelColumn.Type, schemaColumn.Type) {
+			continue
+		}
+
+		differences = append(differences, domain.Difference{
+			Kind:       driftTypeMismatch,
+			Table:      modelTable.Name,
+			Column:     modelColumn.Name,
+			ModelType:  modelColumn.Type,
+			SchemaType: schemaColumn.Type,
+			Source:     getColumnSource(modelColumn),
+		})
+	}
+
+	for _, schemaColumn := range schemaTable.ColumnList {
+		if !modelColumns[strings.ToLower(schemaColumn.Name)] {
+			differences = append(differences, domain.Difference{
+				Kind:       driftColumnMissingFromModels,
+				Table:      modelTable.Name,
+				Column:     schemaColumn.Name,
+				SchemaType: schemaColumn.Type,
+			})
+		}
+	}
+
+	return differences
+}
+
+// isSameDataType returns whether the data type of a column of the models is the same as the one of the schema. Their
+// lengths and precisions are compared only when the models define them (e.g. `varchar(255)` through the mapping of the
+// data types), as the default mapping does not.
+func isSameDataType(modelType, schemaType string) bool {
+	if normalizeDataType(modelType) != normalizeDataType(schemaType) {
+		return false
+	}
+
+	modelArguments := getDataTypeArguments(modelType)
+
+	return modelArguments == "" || modelArguments == getDataTypeArguments(schemaType)
+}
+
+// getDataTypeArguments returns the lengths, precisions and scales of a data type without any spaces (e.g. `(10,2)`),
+// if any.
+func getDataTypeArguments(dataType string) string {
+	return strings.Join(strings.Fields(strings.Join(dataTypeArguments.FindAllString(dataType, -1), "")), "")
+}
+
+// normalizeDataType returns a data type without its lengths and precisions and named after its synonym, if any, so
+// that the same data types are compared equal regardless of the way they are spelled (e.g. `character varying(255)`
+// and `varchar`, or `int4` and `integer`). The data types that differ in the database (e.g. `varchar` and `text`) are
+// kept apart.
+func normalizeDataType(dataType string) string {
+	dataType = strings.ToLower(dataTypeArguments.ReplaceAllString(dataType, ""))
+	dataType = strings.Join(strings.Fields(dataType), " ")
+
+	suffix := ""
+	for _, modifier := range []string{"[]", " unsigned"} {
+		if strings.HasSuffix(dataType, modifier) {
+			dataType = strings.TrimSuffix(dataType, modifier)
+			suffix = modifier
+		}
+	}
+
+	if synonym, found := dataTypeSynonyms[dataType]; found {
+		dataType = synonym
+	}
+
+	return dataType + suffix
+}
+
+// indexTablesByName returns the tables keyed by their names in lower case.
+func indexTablesByName(tableList []domain.Table) map[string]domain.Table {
+	tables := map[string]domain.Table{}
+	for _, table := range tableList {
+		tables[strings.ToLower(table.Name)] = table
+	}
+
+	return tables
+}
+
+// getDriftReferenceKey returns the key that a reference is matched by, consisting of the referencing table and columns
+// and the referenced table, in lower case.
+func getDriftReferenceKey(reference domain.Reference) string {
+	return strings.ToLower(fmt.Sprintf(
+		"%v.%v->%v",
+		reference.FromTableName,
+		strings.Join(reference.GetFromTableColumns(), ","),
+		reference.ToTableName,
+	))
+}
+
+// getTableSource returns the source of the struct of a table (e.g. `models/user.go:12 User`), if known.
+func getTableSource(table domain.Table) string {
+	if table.Source == nil {
+		return ""
+	}
+
+	return table.Source.String()
+}
+
+// getColumnSource returns the source of the field of a column (e.g. `models/user.go:14 User.Email`), if known.
+func getColumnSource(column domain.Column) string {
+	if column.Source == nil {
+		return ""
+	}
+
+	return column.Source.String()
+}
+
+// formatDrift formats the differences between the models and the schema as human readable text.
+func formatDrift(drift domain.Drift) string {
+	if len(drift.Differences) == 0 {
+		return "No drift found between the models and the schema.\n"
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Found %v differences between the models and the schema.\n", len(drift.Differences)))
+	for _, difference := range drift.Differences {
+		var line string
+		switch difference.Kind {
+		case driftTableMissingFromModels:
+			line = fmt.Sprintf("table %v : missing from the models", difference.Table)
+		case driftTableMissingFromSchema:
+			line = fmt.Sprintf("table %v : missing from the schema", difference.Table)
+		case driftColumnMissingFromModels:
+			line = fmt.Sprintf("column %v.%v %v : missing from the models", difference.Table, difference.Column, difference.SchemaType)
+		case driftColumnMissingFromSchema:
+			line = fmt.Sprintf("column %v.%v %v : missing from the schema", difference.Table, difference.Column, difference.ModelType)
+		case driftTypeMismatch:
+			line = fmt.Sprintf(
+				"column %v.%v : %v in the models but %v in the schema",
+				difference.Table,
+				difference.Column,
+				difference.ModelType,
+				difference.SchemaType,
+			)
+		case driftForeignKeyMissingFromModels:
+			line = fmt.Sprintf("foreign key %v.%v -> %v : missing from the models", difference.Table, difference.Column, difference.Reference)
+		}
+
+		// the differences of the models are prefixed by their source when known (e.g. `models/user.go:42 User.Email`).
+		if difference.Source != "" {
+			line = fmt.Sprintf("%v -> %v", difference.Source, line)
+		}
+		builder.WriteString(line + "\n")
+	}
+
+	return builder.String()
+}
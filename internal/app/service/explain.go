@@ -0,0 +1,193 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+	"github.com/eujoy/erbuilder/internal/pkg/inference"
+)
+
+const (
+	explainFormatText = "text"
+	explainFormatJSON = "json"
+)
+
+// ruleReasons describes the way that the references of each rule have been found.
+var ruleReasons = map[string]string{
+	ruleDeclared:           "declared by the tag",
+	ruleAssociation:        "described by an association field",
+	ruleJunction:           "collapsed from a junction table",
+	inference.RuleSuffix:   "inferred by the suffix rule",
+	inference.RuleSelf:     "inferred by the self rule",
+	inference.RuleContains: "inferred by the contains rule",
+	inference.RuleAllow:    "provided by the allow list",
+}
+
+// Explain performs the action to explain why each struct of the provided input became a table (or not), alongside
+// with the decisions taken for its fields and the references found, formatted either as text or as json.
+func (s *Service) Explain() (string, error) {
+	s.explanation = &domain.Explanation{
+		Structs:    []domain.StructExplanation{},
+		References: []domain.ReferenceExplanation{},
+	}
+
+	diagram, err := s.getDiagram()
+	if err != nil {
+		return "", err
+	}
+
+	for _, reference := range diagram.ReferenceList {
+		s.explanation.References = append(s.explanation.References, explainReference(reference))
+	}
+
+	if s.options.ExplainFormat == explainFormatJSON {
+		content, err := json.MarshalIndent(s.explanation, "", "  ")
+		if err != nil {
+			return "", err
+		}
+
+		return string(content), nil
+	}
+
+	return formatExplanation(*s.explanation), nil
+}
+
+// explainStruct records the explanation of a struct declared at the provided position, if explaining.
+func (s *Service) explainStruct(position token.Position, explanation domain.StructExplanation) {
+	if s.explanation == nil {
+		return
+	}
+
+	if source := getSource(position, getStructName(explanation.Name), ""); source != nil {
+		explanation.Source = source.String()
+	}
+
+	s.explanation.Structs = append(s.explanation.Structs, explanation)
+}
+
+// explainField records the explanation of a field mapped to a column, if explaining.
+func (s *Service) explainField(field structField, mappedType, columnName, columnType, reason string) {
+	if s.explanation == nil {
+		return
+	}
+
+	s.fieldExplanations = append(s.fieldExplanations, domain.ColumnExplanation{
+		Field:        field.name,
+		Source:       getFieldSource(field),
+		Tag:          field.tag,
+		CodeType:     getCodeType(field),
+		ResolvedType: mappedType,
+		IsColumn:     true,
+		Column:       columnName,
+		Type:         columnType,
+		Reason:       reason,
+	})
+}
+
+// skipField records the reason that a field has not been mapped to a column (if explaining) and returns no column.
+func (s *Service) skipField(field structField, reason string) (domain.Column, bool) {
+	if s.explanation != nil {
+		s.fieldExplanations = append(s.fieldExplanations, domain.ColumnExplanation{
+			Field:    field.name,
+			Source:   getFieldSource(field),
+			Tag:      field.tag,
+			CodeType: getCodeType(field),
+			Reason:   reason,
+		})
+	}
+
+	return domain.Column{}, false
+}
+
+// getFieldSource returns the source of a field (e.g. `models/user.go:42 User.Email`), if known.
+func getFieldSource(field structField) string {
+	source := getSource(field.position, field.structName, field.name)
+	if source == nil {
+		return ""
+	}
+
+	return source.String()
+}
+
+// getCodeType returns the data type of a field as declared in the code.
+func getCodeType(field structField) string {
+	if field.declaredType != "" {
+		return field.declaredType
+	}
+
+	return field.dataType
+}
+
+// explainReference returns the explanation of a reference by the rule that found it.
+func explainReference(reference domain.Reference) domain.ReferenceExplanation {
+	from := fmt.Sprintf("%v.%v", reference.FromTableName, reference.FromTableColumn)
+	to := reference.ToTableName
+	if reference.ToTableColumn != "" {
+		to = fmt.Sprintf("%v.%v", reference.ToTableName, reference.ToTableColumn)
+	}
+
+	reason := ruleReasons[reference.Rule]
+	if reference.JunctionTable != "" {
+		from = reference.FromTableName
+		reason = fmt.Sprintf("%v %v", reason, reference.JunctionTable)
+	}
+
+	return domain.ReferenceExplanation{
+		From:            from,
+		To:              to,
+		TypeOfReference: reference.TypeOfReference,
+		Rule:            reference.Rule,
+		Reason:          reason,
+	}
+}
+
+// formatExplanation formats an explanation as human readable text.
+func formatExplanation(explanation domain.Explanation) string {
+	var builder strings.Builder
+	builder.WriteString("# Structs.\n")
+	for _, structExplanation := range explanation.Structs {
+		// the structs and the fields are prefixed by their source when known (e.g. `models/user.go:42 User.Email`).
+		name := structExplanation.Name
+		if structExplanation.Source != "" {
+			name = structExplanation.Source
+		}
+
+		if structExplanation.IsTable {
+			builder.WriteString(fmt.Sprintf("%v -> table %v (%v dialect)\n", name, structExplanation.Table, structExplanation.Dialect))
+		} else {
+			builder.WriteString(fmt.Sprintf("%v -> skipped : %v\n", name, structExplanation.Reason))
+		}
+
+		for _, column := range structExplanation.Columns {
+			field := column.Field
+			if column.Source != "" {
+				field = column.Source
+			}
+			if field == "" {
+				field = "-"
+			}
+			if column.Tag != "" {
+				field = fmt.Sprintf("%v `%v`", field, column.Tag)
+			}
+			if column.CodeType != "" {
+				field = fmt.Sprintf("%v %v", field, column.CodeType)
+			}
+
+			if column.IsColumn {
+				builder.WriteString(fmt.Sprintf("\t%v -> column %v %v : %v\n", field, column.Column, column.Type, column.Reason))
+			} else {
+				builder.WriteString(fmt.Sprintf("\t%v -> skipped : %v\n", field, column.Reason))
+			}
+		}
+	}
+
+	builder.WriteString("\n# References.\n")
+	for _, reference := range explanation.References {
+		builder.WriteString(fmt.Sprintf("%v %v %v : %v\n", reference.From, reference.TypeOfReference, reference.To, reference.Reason))
+	}
+
+	return builder.String()
+}
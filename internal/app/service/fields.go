@@ -0,0 +1,607 @@
+package service
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/eujoy/erbuilder/internal/pkg/loader"
+	"github.com/eujoy/erbuilder/internal/pkg/tag"
+)
+
+// structField describes a field of a struct, retrieved either from the syntax tree or from the type information.
+type structField struct {
+	name         string
+	tag          string
+	dataType     string
+	declaredType string
+	embedded     bool
+	columnPrefix string
+
+	// structName and position describe the struct declaring the field and the position of the field in the code.
+	structName string
+	position   token.Position
+
+	// description describes the field, as documented either above it or on its line.
+	description string
+
+	file loader.File
+	expr ast.Expr
+	typ  types.Type
+}
+
+// wellKnownStructs describes the structs of commonly used packages that get embedded into models, so that they can
+// be flattened even when their package is not loaded. Their fields are tagged with their column names for the `db`
+// tag as well, so that the models mapped through it keep the columns of the embedded structs.
+var wellKnownStructs = map[string][]structField{
+	"gorm.io/gorm.Model": {
+		{name: "ID", tag: `gorm:"primarykey" db:"id"`, dataType: "uint"},
+		{name: "CreatedAt", tag: `db:"created_at"`, dataType: "time.Time"},
+		{name: "UpdatedAt", tag: `db:"updated_at"`, dataType: "time.Time"},
+		{name: "DeletedAt", tag: `gorm:"index" db:"deleted_at"`, dataType: "gorm.DeletedAt"},
+	},
+}
+
+// structDefinition describes a struct declared in one of the loaded files.
+type structDefinition struct {
+	file       loader.File
+	structType *ast.StructType
+	typeParams *ast.FieldList
+}
+
+// indexStructs indexes all the structs declared in the loaded files by their qualified name, consisting of the path of
+// their package (e.g. `github.com/acme/app/models.User`), so that the structs of packages sharing a name are told apart.
+func indexStructs(files []loader.File) map[string]structDefinition {
+	index := map[string]structDefinition{}
+	for _, file := range files {
+		for _, decl := range file.Node.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				index[getStructKey(getPackagePath(file), typeSpec.Name.Name)] = structDefinition{
+					file:       file,
+					structType: structType,
+					typeParams: typeSpec.TypeParams,
+				}
+			}
+		}
+	}
+
+	return index
+}
+
+// indexTableNames finds the `TableName() string` methods of the structs declared in the loaded files, returning a
+// literal value, and indexes the table names by the qualified name of the structs.
+func (s *Service) indexTableNames(files []loader.File) map[string]string {
+	index := map[string]string{}
+	for _, file := range files {
+		for _, decl := range file.Node.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Name.Name != "TableName" || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+				continue
+			}
+
+			if funcDecl.Type.Params.NumFields() > 0 || funcDecl.Body == nil || len(funcDecl.Body.List) != 1 {
+				continue
+			}
+
+			returnStmt, ok := funcDecl.Body.List[0].(*ast.ReturnStmt)
+			if !ok || len(returnStmt.Results) != 1 {
+				continue
+			}
+
+			tableName, found := getStringValue(file, returnStmt.Results[0])
+			if !found {
+				continue
+			}
+
+			key, found := s.getQualifiedName(file, funcDecl.Recv.List[0].Type)
+			if found {
+				index[key] = tableName
+			}
+		}
+	}
+
+	return index
+}
+
+// getStringValue returns the value of a string expression, either a literal or (if type information is available)
+// a constant.
+func getStringValue(file loader.File, expr ast.Expr) (string, bool) {
+	if file.TypesInfo != nil {
+		if tv, found := file.TypesInfo.Types[expr]; found && tv.Value != nil && tv.Value.Kind() == constant.String {
+			return constant.StringVal(tv.Value), true
+		}
+	}
+
+	literal, ok := expr.(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING {
+		return "", false
+	}
+
+	value, err := strconv.Unquote(literal.Value)
+	if err != nil {
+		return "", false
+	}
+
+	return value, true
+}
+
+// findEmbeddedStructs returns the qualified names of all the indexed structs that are embedded in other structs without
+// being models themselves (e.g. mixins of timestamps).
+//
+// These structs are flattened into the structs embedding them, so they are not considered as tables on their own. The
+// embedded structs that are models as well (e.g. `User` embedded in `AdminUser`), either by being used as the type of
+// other fields (e.g. `Author *User`) or by declaring their table name, remain tables on their own.
+func (s *Service) findEmbeddedStructs() map[string]bool {
+	embeddedFields := map[string]bool{}
+	unembeddedFields := map[string]bool{}
+	for _, def := range s.structIndex {
+		for _, field := range getFieldsFromSyntax(def.file, def.structType) {
+			if _, isEmbedded := s.getEmbeddedPrefix(field); !isEmbedded {
+				if key, found := s.getQualifiedName(def.file, unwrapExpr(field.expr)); found {
+					unembeddedFields[key] = true
+				}
+				continue
+			}
+
+			if key, _, found := s.lookupStructFields(field); found {
+				embeddedFields[key] = true
+			}
+		}
+	}
+
+	embedded := map[string]bool{}
+	for key := range embeddedFields {
+		if !unembeddedFields[key] && !s.isModel(key) {
+			embedded[key] = true
+		}
+	}
+
+	return embedded
+}
+
+// isModel checks whether an indexed struct describes a table on its own, having either a `TableName()` method or a
+// table name declared in its tags. A primary key declared in its tags is not enough, since the mixins commonly declare
+// one as well (e.g. the `ID` of a `Base` struct).
+func (s *Service) isModel(structKey string) bool {
+	if _, found := s.tableNames[structKey]; found {
+		return true
+	}
+
+	def, found := s.structIndex[structKey]
+	if !found {
+		return false
+	}
+
+	declaredFields := getFieldsFromSyntax(def.file, def.structType)
+	_, found = getDeclaredTableName(s.getDialect(declaredFields), declaredFields)
+
+	return found
+}
+
+// flattenFields replaces every embedded struct field with the (recursively flattened) fields of the struct.
+func (s *Service) flattenFields(dialect tag.Dialect, fields []structField, columnPrefix string, visited map[string]bool) []structField {
+	var flattened []structField
+	for _, field := range fields {
+		if isIgnored(dialect, field) {
+			s.skipField(field, "ignored by the tag")
+			continue
+		}
+
+		// the fields of anonymous structs are flattened as well, unless the field is a column on its own.
+		if _, tagged := reflect.StructTag(field.tag).Lookup(dialect.Key()); !tagged {
+			if anonymousFields, found := getAnonymousStructFields(s.fset, field); found {
+				for idx := range anonymousFields {
+					anonymousFields[idx].structName = field.structName
+				}
+				flattened = append(flattened, s.flattenFields(dialect, anonymousFields, columnPrefix, visited)...)
+				continue
+			}
+		}
+
+		prefix, isEmbedded := s.getEmbeddedPrefix(field)
+		if isEmbedded {
+			key, embeddedFields, found := s.lookupStructFields(field)
+			if found && !visited[key] {
+				for idx := range embeddedFields {
+					embeddedFields[idx].structName = getStructName(key)
+				}
+				visited[key] = true
+				flattened = append(flattened, s.flattenFields(dialect, embeddedFields, columnPrefix+prefix, visited)...)
+				delete(visited, key)
+				continue
+			}
+		}
+
+		field.columnPrefix = columnPrefix
+		flattened = append(flattened, field)
+	}
+
+	return flattened
+}
+
+// isIgnored checks whether a field is explicitly excluded from the columns through its tag (e.g. `db:"-"`).
+func isIgnored(dialect tag.Dialect, field structField) bool {
+	value, found := reflect.StructTag(field.tag).Lookup(dialect.Key())
+	if !found {
+		return false
+	}
+
+	return dialect.ParseColumn(value).Ignore
+}
+
+// lookupStructFields finds the struct that the type of a field refers to and returns its qualified name and fields.
+func (s *Service) lookupStructFields(field structField) (string, []structField, bool) {
+	tp := field.typ
+	if tp == nil && field.file.TypesInfo != nil {
+		tp = field.file.TypesInfo.TypeOf(field.expr)
+	}
+
+	if tp != nil {
+		return s.lookupStructFieldsFromType(tp)
+	}
+
+	key, found := s.getQualifiedName(field.file, field.expr)
+	if !found {
+		return "", []structField{}, false
+	}
+
+	def, found := s.structIndex[key]
+	if !found {
+		wellKnownFields, found := wellKnownStructs[key]
+		return key, wellKnownFields, found
+	}
+
+	return key, instantiateFields(field, def, getFieldsFromSyntax(def.file, def.structType)), true
+}
+
+// instantiateFields replaces the type parameters of a generic struct in its fields with the type arguments that the
+// struct has been instantiated with (e.g. `Entity[int64]`).
+func instantiateFields(field structField, def structDefinition, fields []structField) []structField {
+	var typeArgs []ast.Expr
+	switch t := unwrapPointer(field.expr).(type) {
+	case *ast.IndexExpr:
+		typeArgs = []ast.Expr{t.Index}
+	case *ast.IndexListExpr:
+		typeArgs = t.Indices
+	}
+
+	if def.typeParams == nil || len(typeArgs) == 0 {
+		return fields
+	}
+
+	typeArgsByName := map[string]ast.Expr{}
+	idx := 0
+	for _, param := range def.typeParams.List {
+		for _, name := range param.Names {
+			if idx < len(typeArgs) {
+				typeArgsByName[name.Name] = typeArgs[idx]
+			}
+			idx++
+		}
+	}
+
+	for i := range fields {
+		ident, ok := fields[i].expr.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		if typeArg, found := typeArgsByName[ident.Name]; found {
+			fields[i].file = field.file
+			fields[i].expr = typeArg
+			fields[i].dataType = field.file.ResolveType(typeArg)
+			fields[i].declaredType = field.file.DeclaredType(typeArg)
+		}
+	}
+
+	return fields
+}
+
+// getAnonymousStructFields returns the fields of a field whose type is an anonymous struct (e.g. `Meta struct{...}`).
+func getAnonymousStructFields(fset *token.FileSet, field structField) ([]structField, bool) {
+	if field.typ != nil {
+		structType, ok := types.Unalias(field.typ).(*types.Struct)
+		if !ok {
+			return []structField{}, false
+		}
+		return getFieldsFromTypes(fset, structType), true
+	}
+
+	structType, ok := field.expr.(*ast.StructType)
+	if !ok {
+		return []structField{}, false
+	}
+
+	return getFieldsFromSyntax(field.file, structType), true
+}
+
+// getReferencedModel returns the qualified name of the model struct that a field refers to, either directly, through
+// a pointer or as a list of them (e.g. `User`, `*User` or `[]User`).
+func (s *Service) getReferencedModel(field structField) (string, bool) {
+	if field.typ != nil || field.file.TypesInfo != nil {
+		tp := field.typ
+		if tp == nil {
+			tp = field.file.TypesInfo.TypeOf(field.expr)
+		}
+
+		named, ok := unwrapType(tp).(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			return "", false
+		}
+
+		key := getStructKey(named.Obj().Pkg().Path(), named.Obj().Name())
+		_, found := s.structIndex[key]
+		return key, found
+	}
+
+	key, found := s.getQualifiedName(field.file, unwrapExpr(field.expr))
+	if !found {
+		return "", false
+	}
+
+	_, found = s.structIndex[key]
+	return key, found
+}
+
+// unwrapPointer removes any pointers around a type expression.
+func unwrapPointer(expr ast.Expr) ast.Expr {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return unwrapPointer(star.X)
+	}
+
+	return expr
+}
+
+// unwrapExpr removes any pointers, slices and arrays around a type expression.
+func unwrapExpr(expr ast.Expr) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return unwrapExpr(t.X)
+	case *ast.ArrayType:
+		return unwrapExpr(t.Elt)
+	default:
+		return expr
+	}
+}
+
+// unwrapType removes any aliases, pointers, slices and arrays around a type.
+func unwrapType(tp types.Type) types.Type {
+	if tp == nil {
+		return nil
+	}
+
+	switch t := types.Unalias(tp).(type) {
+	case *types.Pointer:
+		return unwrapType(t.Elem())
+	case *types.Slice:
+		return unwrapType(t.Elem())
+	case *types.Array:
+		return unwrapType(t.Elem())
+	default:
+		return t
+	}
+}
+
+// lookupStructFieldsFromType returns the fields of a struct type, preferring the syntax tree if it has been loaded.
+func (s *Service) lookupStructFieldsFromType(tp types.Type) (string, []structField, bool) {
+	tp = types.Unalias(tp)
+	if pointer, ok := tp.(*types.Pointer); ok {
+		tp = types.Unalias(pointer.Elem())
+	}
+
+	named, ok := tp.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return "", []structField{}, false
+	}
+
+	// the fields of instantiated generic structs are retrieved from the type information, where the type parameters
+	// have been substituted.
+	key := getStructKey(named.Obj().Pkg().Path(), named.Obj().Name())
+	if def, found := s.structIndex[key]; found && named.TypeArgs().Len() == 0 {
+		return key, getFieldsFromSyntax(def.file, def.structType), true
+	}
+
+	structType, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return "", []structField{}, false
+	}
+
+	return key, getFieldsFromTypes(s.fset, structType), true
+}
+
+// getFieldsFromSyntax returns the fields of a struct as declared in the syntax tree.
+func getFieldsFromSyntax(file loader.File, structType *ast.StructType) []structField {
+	var fields []structField
+	for _, field := range structType.Fields.List {
+		tag := ""
+		if field.Tag != nil {
+			tag, _ = strconv.Unquote(field.Tag.Value)
+		}
+
+		newField := structField{
+			tag:          tag,
+			dataType:     file.ResolveType(field.Type),
+			declaredType: file.DeclaredType(field.Type),
+			embedded:     len(field.Names) == 0,
+			position:     getPosition(file.Fset, field.Type.Pos()),
+			description:  getDescription(field.Doc, field.Comment),
+			file:         file,
+			expr:         field.Type,
+		}
+
+		if newField.embedded {
+			fields = append(fields, newField)
+			continue
+		}
+
+		for _, name := range field.Names {
+			newField.name = name.Name
+			newField.position = getPosition(file.Fset, name.Pos())
+			fields = append(fields, newField)
+		}
+	}
+
+	return fields
+}
+
+// getFieldsFromTypes returns the fields of a struct as described by the type information, positioned within the
+// provided file set.
+func getFieldsFromTypes(fset *token.FileSet, structType *types.Struct) []structField {
+	var fields []structField
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		fields = append(fields, structField{
+			name:         field.Name(),
+			tag:          structType.Tag(i),
+			dataType:     loader.TypeName(field.Type()),
+			declaredType: loader.DeclaredTypeName(field.Type()),
+			embedded:     field.Embedded(),
+			position:     getPosition(fset, field.Pos()),
+			typ:          field.Type(),
+		})
+	}
+
+	return fields
+}
+
+// getEmbeddedPrefix checks whether the fields of a struct field need to be flattened, either because it is embedded
+// or because of the respective tag option (e.g. `gorm:"embedded"`), and returns the prefix to apply to the columns.
+func (s *Service) getEmbeddedPrefix(field structField) (string, bool) {
+	prefix, isEmbedded := s.dialects.Embedding(field.tag)
+	return prefix, isEmbedded || field.embedded
+}
+
+// getQualifiedName returns the qualified name of the type that an expression refers to, consisting of the path of its
+// package (e.g. `github.com/acme/app/models.User`).
+func (s *Service) getQualifiedName(file loader.File, expr ast.Expr) (string, bool) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return s.getQualifiedName(file, t.X)
+	case *ast.IndexExpr:
+		return s.getQualifiedName(file, t.X)
+	case *ast.IndexListExpr:
+		return s.getQualifiedName(file, t.X)
+	case *ast.Ident:
+		return getStructKey(getPackagePath(file), t.Name), true
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return "", false
+		}
+		return s.resolveStructKey(getImportPath(file, pkg.Name), t.Sel.Name), true
+	default:
+		return "", false
+	}
+}
+
+// getStructKey returns the qualified name of a struct by the path of its package.
+func getStructKey(packagePath, structName string) string {
+	return fmt.Sprintf("%v.%v", packagePath, structName)
+}
+
+// getPackagePath returns the import path of the package of a file, or the directory of the file when parsed on its own.
+func getPackagePath(file loader.File) string {
+	if file.PkgPath != "" {
+		return file.PkgPath
+	}
+
+	return filepath.ToSlash(filepath.Dir(file.Path))
+}
+
+// getImportPath returns the import path of a package as imported by a file under the provided name, or the name itself
+// if not imported by the file.
+func getImportPath(file loader.File, name string) string {
+	for _, spec := range file.Node.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		importName := path.Base(importPath)
+		if spec.Name != nil {
+			importName = spec.Name.Name
+		}
+
+		if importName == name {
+			return importPath
+		}
+	}
+
+	return name
+}
+
+// resolveStructKey returns the qualified name of a struct declared in the package of an import path. The files parsed
+// on their own are identified by their directory instead, so the import path is matched to the directory declaring the
+// struct and sharing the most trailing elements with it (e.g. `github.com/acme/app/models` to `./app/models`), or kept
+// as it is if there is none (e.g. for the well known structs).
+func (s *Service) resolveStructKey(importPath, structName string) string {
+	structKey := getStructKey(importPath, structName)
+	if _, found := s.structIndex[structKey]; found {
+		return structKey
+	}
+
+	importElements := strings.Split(importPath, "/")
+	maxMatches := 0
+	for packagePath := range s.packagePaths {
+		candidateKey := getStructKey(packagePath, structName)
+		if _, found := s.structIndex[candidateKey]; !found {
+			continue
+		}
+
+		packageElements := strings.Split(packagePath, "/")
+		matches := 0
+		for matches < len(importElements) && matches < len(packageElements) &&
+			importElements[len(importElements)-1-matches] == packageElements[len(packageElements)-1-matches] {
+			matches++
+		}
+
+		if matches > maxMatches || (matches == maxMatches && matches > 0 && candidateKey < structKey) {
+			structKey = candidateKey
+			maxMatches = matches
+		}
+	}
+
+	return structKey
+}
+
+// getPosition returns the position in the code of a node, if known.
+func getPosition(fset *token.FileSet, pos token.Pos) token.Position {
+	if fset == nil || !pos.IsValid() {
+		return token.Position{}
+	}
+
+	return fset.Position(pos)
+}
+
+// getDescription returns the text of the first provided comment which is not empty, in a single line.
+func getDescription(comments ...*ast.CommentGroup) string {
+	for _, comment := range comments {
+		if description := strings.Join(strings.Fields(comment.Text()), " "); description != "" {
+			return description
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+)
+
+const (
+	junctionTablesKeep     = "keep"
+	junctionTablesCollapse = "collapse"
+
+	ruleJunction = "junction"
+
+	typeOfJunctionReference = cardinalityZeroOrMore + "--" + cardinalityExactlyOne
+	typeOfManyToMany        = cardinalityZeroOrMore + "--" + cardinalityZeroOrMore
+)
+
+// timestampTypes describes the data types of the timestamp columns that a junction table may have, apart from its
+// foreign keys.
+var timestampTypes = map[string]bool{
+	"date": true, "datetime": true, "time": true, "timestamp": true, "timestamptz": true,
+}
+
+// applyJunctionTables detects the junction tables of the many to many relations, meaning the tables consisting only of
+// two foreign keys and optionally some timestamps (e.g. `user_role` with `user_id`, `role_id` and `created_at`).
+//
+// Depending on the mode, the junction tables are either kept with each of their rows referring to exactly one row of
+// the respective table (`*--1`), or collapsed to a many to many reference between the two tables (`*--*`).
+func (s *Service) applyJunctionTables(diagram *domain.Diagram) {
+	if s.options.JunctionTables != junctionTablesKeep && s.options.JunctionTables != junctionTablesCollapse {
+		return
+	}
+
+	for _, table := range diagram.TableList {
+		referenceIndexes, isJunction := getJunctionReferences(diagram, table)
+		if !isJunction {
+			continue
+		}
+
+		if s.options.JunctionTables == junctionTablesKeep {
+			for _, idx := range referenceIndexes {
+				if diagram.ReferenceList[idx].Cardinality == "" {
+					diagram.ReferenceList[idx].TypeOfReference = typeOfJunctionReference
+				}
+			}
+			continue
+		}
+
+		collapseJunctionTable(diagram, table.Name, referenceIndexes)
+	}
+}
+
+// getJunctionReferences returns the indexes of the references of a table to the tables it joins, if it is a junction
+// table. A table referred to by other tables is never a junction table, since collapsing it would lose the references.
+func getJunctionReferences(diagram *domain.Diagram, table domain.Table) ([]int, bool) {
+	referenceIndexes := map[string]int{}
+	for idx, reference := range diagram.ReferenceList {
+		if reference.ToTableName == table.Name {
+			return nil, false
+		}
+
+		if reference.FromTableName != table.Name {
+			continue
+		}
+
+		if len(reference.GetFromTableColumns()) != 1 {
+			return nil, false
+		}
+		referenceIndexes[reference.FromTableColumn] = idx
+	}
+
+	if len(referenceIndexes) != 2 {
+		return nil, false
+	}
+
+	// the references are returned in the order of their columns in the table.
+	var orderedIndexes []int
+	for _, column := range table.ColumnList {
+		if idx, found := referenceIndexes[column.Name]; found {
+			orderedIndexes = append(orderedIndexes, idx)
+			continue
+		}
+
+		if !column.IsExtraField && !isTimestampColumn(column) {
+			return nil, false
+		}
+	}
+
+	return orderedIndexes, len(orderedIndexes) == 2
+}
+
+// isTimestampColumn checks whether a column is a timestamp, either by its data type or by its name (e.g. `created_at`).
+func isTimestampColumn(column domain.Column) bool {
+	columnType := strings.ToLower(column.Type)
+	if parenthesis := strings.Index(columnType, "("); parenthesis >= 0 {
+		columnType = columnType[:parenthesis]
+	}
+
+	return timestampTypes[columnType] || strings.HasSuffix(strings.ToLower(column.Name), "_at")
+}
+
+// collapseJunctionTable removes a junction table alongside with its references from the diagram, replacing them with a
+// many to many reference between the tables it joins.
+func collapseJunctionTable(diagram *domain.Diagram, tableName string, referenceIndexes []int) {
+	manyToMany := domain.Reference{
+		FromTableName:   diagram.ReferenceList[referenceIndexes[0]].ToTableName,
+		ToTableName:     diagram.ReferenceList[referenceIndexes[1]].ToTableName,
+		TypeOfReference: typeOfManyToMany,
+		Rule:            ruleJunction,
+		JunctionTable:   tableName,
+	}
+
+	var referenceList []domain.Reference
+	isReplaced := false
+	for _, reference := range diagram.ReferenceList {
+		if reference.FromTableName != tableName {
+			referenceList = append(referenceList, reference)
+			continue
+		}
+
+		if !isReplaced {
+			referenceList = append(referenceList, manyToMany)
+			isReplaced = true
+		}
+	}
+	diagram.ReferenceList = referenceList
+
+	var tableList []domain.Table
+	for _, table := range diagram.TableList {
+		if table.Name != tableName {
+			tableList = append(tableList, table)
+		}
+	}
+	diagram.TableList = tableList
+}
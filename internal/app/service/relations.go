@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+)
+
+const (
+	cardinalityZeroOrOne  = "?"
+	cardinalityExactlyOne = "1"
+	cardinalityZeroOrMore = "*"
+	cardinalityOneOrMore  = "+"
+)
+
+// multiplicities maps the multiplicities that a cardinality can be declared with to the erd notation.
+var multiplicities = map[string]string{
+	"0..1": cardinalityZeroOrOne,
+	"1":    cardinalityExactlyOne,
+	"1..1": cardinalityExactlyOne,
+	"*":    cardinalityZeroOrMore,
+	"0..*": cardinalityZeroOrMore,
+	"0..n": cardinalityZeroOrMore,
+	"n":    cardinalityZeroOrMore,
+	"1..*": cardinalityOneOrMore,
+	"1..n": cardinalityOneOrMore,
+	"?":    cardinalityZeroOrOne,
+	"+":    cardinalityOneOrMore,
+}
+
+// getAssociationKey returns the key of the association of a parent table to a child table.
+func getAssociationKey(parentTable, childTable string) string {
+	return fmt.Sprintf("%v->%v", parentTable, childTable)
+}
+
+// setTypesOfReferences sets the erd notation (e.g. `*--1`) of every reference, either based on the declared
+// cardinality or inferred from the model.
+func (s *Service) setTypesOfReferences(diagram *domain.Diagram) {
+	columns := map[string]domain.Column{}
+	primaryKeys := map[string]int{}
+	for _, table := range diagram.TableList {
+		for _, column := range table.ColumnList {
+			columns[fmt.Sprintf("%v.%v", table.Name, column.Name)] = column
+			if column.IsPrimaryKey {
+				primaryKeys[table.Name]++
+			}
+		}
+	}
+
+	for idx := range diagram.ReferenceList {
+		reference := &diagram.ReferenceList[idx]
+
+		// a composite foreign key is nullable if any of its columns is, and unique if it is the whole primary key.
+		isNullable, primaryKeyColumns := false, 0
+		fromTableColumns := reference.GetFromTableColumns()
+		for _, columnName := range fromTableColumns {
+			column := columns[fmt.Sprintf("%v.%v", reference.FromTableName, columnName)]
+			isNullable = isNullable || column.IsNullable
+			if column.IsPrimaryKey {
+				primaryKeyColumns++
+			}
+		}
+
+		isOneToOne := primaryKeyColumns == len(fromTableColumns) && primaryKeys[reference.FromTableName] == primaryKeyColumns
+		if len(fromTableColumns) == 1 {
+			isOneToOne = isOneToOne || columns[fmt.Sprintf("%v.%v", reference.FromTableName, fromTableColumns[0])].IsUnique
+		}
+
+		reference.TypeOfReference = getTypeOfReference(
+			reference.Cardinality,
+			isNullable,
+			isOneToOne,
+			s.associations[getAssociationKey(reference.ToTableName, reference.FromTableName)],
+		)
+	}
+}
+
+// getTypeOfReference returns the erd notation of a reference (e.g. `+--1`).
+//
+// The declared cardinality is either the multiplicity of the referencing rows per referenced row (e.g. `1..*`) or the
+// erd notation itself. Otherwise, the multiplicity is inferred from the association fields of the referenced model
+// (e.g. `Addresses []Address` for many, `Profile *Profile` for one), which take precedence over the uniqueness of the
+// foreign key. Each referencing row refers to exactly one row, or at most one if the foreign key is nullable.
+func getTypeOfReference(cardinality string, isNullable, isOneToOne bool, association string) string {
+	if strings.Contains(cardinality, "--") {
+		return cardinality
+	}
+
+	from, found := multiplicities[cardinality]
+	if !found {
+		switch {
+		case association == associationMany:
+			from = cardinalityZeroOrMore
+		case association == associationOne, isOneToOne:
+			from = cardinalityZeroOrOne
+		default:
+			from = cardinalityZeroOrMore
+		}
+	}
+
+	to := cardinalityExactlyOne
+	if isNullable {
+		to = cardinalityZeroOrOne
+	}
+
+	return fmt.Sprintf("%v--%v", from, to)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+	"github.com/eujoy/erbuilder/internal/pkg/ddl"
+	"github.com/eujoy/erbuilder/internal/pkg/introspect"
+)
+
+// getSchemaDiagram replays the provided migrations (up to the provided version, if any) and then the sql files in
+// order, returning the diagram of the tables they define. The references of the diagram are the declared foreign keys
+// of the tables, without inferring any.
+func (s *Service) getSchemaDiagram() (domain.Diagram, error) {
+	schema := ddl.NewSchema()
+	if s.options.Migrations != "" {
+		err := schema.ApplyMigrations(s.options.Migrations, s.options.MigrationVersion)
+		if err != nil {
+			return domain.Diagram{}, err
+		}
+	}
+
+	for _, filename := range s.options.SQLFiles.Value() {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return domain.Diagram{}, err
+		}
+
+		err = schema.Apply(string(content))
+		if err != nil {
+			return domain.Diagram{}, fmt.Errorf("%v: %v", filename, err)
+		}
+	}
+
+	return s.getDiagramOfTables(schema.Tables()), nil
+}
+
+// getSQLiteDiagram reads the tables of the provided sqlite database and returns their diagram, with the foreign keys
+// declared in the database as references.
+func (s *Service) getSQLiteDiagram() (domain.Diagram, error) {
+	db, err := introspect.OpenSQLite(s.options.SQLite)
+	if err != nil {
+		return domain.Diagram{}, err
+	}
+	defer db.Close()
+
+	tableList, err := introspect.SQLite(db)
+	if err != nil {
+		return domain.Diagram{}, err
+	}
+
+	return s.getDiagramOfTables(tableList), nil
+}
+
+// Introspect performs the action to read the tables of the provided database and generate the .er file describing
+// them, with the foreign keys declared in the database as references.
+func (s *Service) Introspect() error {
+	diagram, err := s.getDatabaseDiagram()
+	if err != nil {
+		return err
+	}
+
+	return s.writer.WriteFile(diagram)
+}
+
+// getDatabaseDiagram connects to the provided database and returns the diagram of the tables of the provided schema, as
+// listed by its information schema (or by the pragmas of sqlite).
+func (s *Service) getDatabaseDiagram() (domain.Diagram, error) {
+	db, err := sql.Open(s.options.Driver, s.options.DSN)
+	if err != nil {
+		return domain.Diagram{}, err
+	}
+	defer db.Close()
+
+	tableList, err := s.getDatabaseTables(db)
+	if err != nil {
+		return domain.Diagram{}, err
+	}
+
+	return s.getDiagramOfTables(tableList), nil
+}
+
+// getDatabaseTables returns the tables of the provided database, read according to its driver.
+func (s *Service) getDatabaseTables(db *sql.DB) ([]domain.Table, error) {
+	if s.options.Driver == introspect.SQLiteDriver {
+		return introspect.SQLite(db)
+	}
+
+	informationSchema, err := introspect.NewInformationSchema(db, s.options.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return informationSchema.Tables(s.options.Schema)
+}
+
+// getDiagramOfTables returns the diagram of tables retrieved from a database schema, referring to each other through
+// their declared foreign keys.
+func (s *Service) getDiagramOfTables(tableList []domain.Table) domain.Diagram {
+	if !s.options.Descriptions {
+		for idxTb := range tableList {
+			tableList[idxTb].Description = ""
+			for idxCol := range tableList[idxTb].ColumnList {
+				tableList[idxTb].ColumnList[idxCol].Description = ""
+			}
+		}
+	}
+
+	diagram := domain.Diagram{Title: s.options.Title, TableList: tableList}
+	diagram.ReferenceList = getDeclaredReferences(&diagram)
+
+	s.associations = map[string]string{}
+	s.setTypesOfReferences(&diagram)
+	s.applyJunctionTables(&diagram)
+
+	return diagram
+}
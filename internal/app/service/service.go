@@ -5,17 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
-	"go/parser"
 	"go/token"
 	"io/ioutil"
 	"log"
 	"path/filepath"
 	"reflect"
-	"regexp"
 	"strings"
 
 	externalSurvey "github.com/AlecAivazis/survey/v2"
 	"github.com/eujoy/erbuilder/internal/domain"
+	"github.com/eujoy/erbuilder/internal/pkg/inference"
+	"github.com/eujoy/erbuilder/internal/pkg/loader"
+	"github.com/eujoy/erbuilder/internal/pkg/tag"
 	"gopkg.in/go-playground/colors.v1"
 )
 
@@ -24,6 +25,11 @@ const (
 	columnTypeVarchar = "varchar"
 	columnTypeOther   = "other"
 
+	defaultTag     = "db"
+	defaultIDField = "id"
+	tagAuto        = "auto"
+	ruleDeclared   = "declared"
+
 	addMoreTable   = "Table"
 	addMoreColumn  = "Column"
 	addMoreNothing = "Nothing"
@@ -91,6 +97,11 @@ var columnDefinitionQuestion = []*externalSurvey.Question{
 	},
 }
 
+type packageLoader interface {
+	LoadFiles(fileList []string) ([]loader.File, error)
+	LoadPackages(patterns []string) ([]loader.File, error)
+}
+
 type survey interface {
 	AskTableDetails(questions []*externalSurvey.Question) (domain.TableAnswer, error)
 	AskColumnDetails(questions []*externalSurvey.Question) (domain.ColumnAnswer, error)
@@ -99,7 +110,8 @@ type survey interface {
 type util interface {
 	GetCaseOfString(initialValue, convertToCase string) string
 	GetValueCount(isPlural bool, initialValue string) string
-	GetDBDataTypeFromCodeDataType(dataType string) string
+	LookupDBDataType(dataType, dialect string) (string, bool)
+	ResolveNullableDataType(dataType string) (string, bool)
 }
 
 type writer interface {
@@ -109,38 +121,47 @@ type writer interface {
 // Service describes the service flow.
 type Service struct {
 	options domain.Options
+	loader  packageLoader
 	survey  survey
 	util    util
 	writer  writer
+
+	dialects *tag.Registry
+
+	structIndex     map[string]structDefinition
+	packagePaths    map[string]bool
+	tableNames      map[string]string
+	embeddedStructs map[string]bool
+	dataTypeMapping map[string]string
+	inference       *inference.Engine
+	associations    map[string]string
+	associationList []association
+	fset            *token.FileSet
+
+	explanation       *domain.Explanation
+	fieldExplanations []domain.ColumnExplanation
 }
 
 // New creates and returns a new service.
-func New(options domain.Options, survey survey, util util, writer writer) *Service {
+func New(options domain.Options, loader packageLoader, survey survey, util util, writer writer) *Service {
 	return &Service{
 		options: options,
+		loader:  loader,
 		survey:  survey,
 		util:    util,
 		writer:  writer,
+
+		dialects: tag.NewRegistry(),
 	}
 }
 
 // Generate performs the action to generate the .er file based on the provided input.
 func (s *Service) Generate() error {
-	filesToParse := defineFilesToParse(s.options.Directory, s.options.FileList.Value())
-	diagram := domain.Diagram{Title: s.options.Title}
-
-	for _, fl := range filesToParse {
-		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, fl, nil, parser.ParseComments)
-		if err != nil {
-			return err
-		}
-
-		diagram.TableList = append(diagram.TableList, s.getAllTables(node.Decls)...)
+	diagram, err := s.getDiagram()
+	if err != nil {
+		return err
 	}
 
-	s.enrichForeignKeyReferences(&diagram)
-
 	if s.options.ExtraTablesSurvey {
 		extraTables, err := s.Build()
 		if err != nil {
@@ -158,7 +179,7 @@ func (s *Service) Generate() error {
 		diagram.TableList = append(diagram.TableList, extraTables...)
 	}
 
-	err := s.writer.WriteFile(diagram)
+	err = s.writer.WriteFile(diagram)
 	if err != nil {
 		return err
 	}
@@ -166,6 +187,65 @@ func (s *Service) Generate() error {
 	return nil
 }
 
+// getDiagram loads the provided files (or reads the provided database, or replays the provided migrations and sql
+// files instead) and returns the diagram of the tables and the references found in them.
+func (s *Service) getDiagram() (domain.Diagram, error) {
+	if s.options.SQLite != "" {
+		return s.getSQLiteDiagram()
+	}
+
+	if len(s.options.SQLFiles.Value()) > 0 || s.options.Migrations != "" {
+		return s.getSchemaDiagram()
+	}
+
+	return s.getStructDiagram()
+}
+
+// getStructDiagram loads the provided files and returns the diagram of the tables defined by their structs, alongside
+// with the references declared or inferred between them.
+func (s *Service) getStructDiagram() (domain.Diagram, error) {
+	files, err := s.loadFiles()
+	if err != nil {
+		return domain.Diagram{}, err
+	}
+
+	s.dataTypeMapping, err = loadDataTypeMapping(s.options.TypeMappingFile)
+	if err != nil {
+		return domain.Diagram{}, err
+	}
+
+	s.inference, err = inference.New(
+		s.util,
+		s.options.TableNamePlural,
+		s.options.ForeignKeyRules.Value(),
+		s.options.ForeignKeyAllowList.Value(),
+		s.options.ForeignKeyDenyList.Value(),
+	)
+	if err != nil {
+		return domain.Diagram{}, err
+	}
+
+	s.structIndex = indexStructs(files)
+	s.packagePaths = map[string]bool{}
+	for _, file := range files {
+		s.packagePaths[getPackagePath(file)] = true
+	}
+	s.tableNames = s.indexTableNames(files)
+	s.embeddedStructs = s.findEmbeddedStructs()
+	s.associations = map[string]string{}
+	s.associationList = []association{}
+
+	diagram := domain.Diagram{Title: s.options.Title}
+	for _, fl := range files {
+		diagram.TableList = append(diagram.TableList, s.getAllTables(fl)...)
+	}
+
+	s.enrichForeignKeyReferences(&diagram)
+	s.applyJunctionTables(&diagram)
+
+	return diagram, nil
+}
+
 // Build performs the action to build extra details for the cli tool.
 func (s *Service) Build() ([]domain.Table, error) {
 	var tableList []domain.Table
@@ -219,80 +299,216 @@ func (s *Service) Build() ([]domain.Table, error) {
 	return tableList, nil
 }
 
+// loadFiles loads all the go files, either parsed on their own or as part of packages with full type information.
+func (s *Service) loadFiles() ([]loader.File, error) {
+	var files []loader.File
+
+	filesToParse := defineFilesToParse(s.options.Directory, s.options.FileList.Value())
+	if len(filesToParse) > 0 {
+		parsedFiles, err := s.loader.LoadFiles(filesToParse)
+		if err != nil {
+			return []loader.File{}, err
+		}
+		files = append(files, parsedFiles...)
+	}
+
+	if len(s.options.Packages.Value()) > 0 {
+		packageFiles, err := s.loader.LoadPackages(s.options.Packages.Value())
+		if err != nil {
+			return []loader.File{}, err
+		}
+		files = append(files, packageFiles...)
+
+		// the packages share a single file set, positioning the fields retrieved from the type information as well.
+		if len(packageFiles) > 0 {
+			s.fset = packageFiles[0].Fset
+		}
+	}
+
+	return files, nil
+}
+
 // getAllTables retrieves and returns all the table definitions with their columns.
-func (s *Service) getAllTables(declarations []ast.Decl) []domain.Table {
+func (s *Service) getAllTables(file loader.File) []domain.Table {
 	var tableList []domain.Table
+	declarations := file.Node.Decls
 	for i := 0; i < len(declarations); i++ {
 		if reflect.TypeOf(declarations[i]) != reflect.TypeOf(&ast.GenDecl{}) {
 			continue
 		}
 		typeDecl := declarations[i].(*ast.GenDecl)
 
-		tableDefinition, found := s.getTableDefinition(typeDecl.Specs)
-		if found {
-			tableList = append(tableList, tableDefinition)
+		// a single declaration may group several types (e.g. `type ( User struct{...}; Order struct{...} )`), while
+		// the doc comment of a single type is attached to the declaration instead.
+		for _, spec := range typeDecl.Specs {
+			var doc *ast.CommentGroup
+			if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+				doc = typeSpec.Doc
+			}
+			if len(typeDecl.Specs) == 1 && !typeDecl.Lparen.IsValid() {
+				doc = typeDecl.Doc
+			}
+
+			tableDefinition, found := s.getTableDefinition(file, spec, doc)
+			if found {
+				tableList = append(tableList, tableDefinition)
+			}
 		}
 	}
 
 	return tableList
 }
 
-// getTableDefinition retrieves the definition of a table alongside with it's columns and returns it.
-func (s *Service) getTableDefinition(typeSpec []ast.Spec) (domain.Table, bool) {
+// getTableDefinition retrieves the definition of a table alongside with it's columns and returns it, described by the
+// provided doc comment of the struct.
+func (s *Service) getTableDefinition(file loader.File, spec ast.Spec, doc *ast.CommentGroup) (domain.Table, bool) {
 	var tableDetails domain.Table
-	tagRegexp := getTagRegexp(s.options.Tag)
 
-	if reflect.TypeOf(typeSpec[0]) != reflect.TypeOf(&ast.TypeSpec{}) {
+	if reflect.TypeOf(spec) != reflect.TypeOf(&ast.TypeSpec{}) {
 		return tableDetails, false
 	}
 
-	if reflect.TypeOf(typeSpec[0].(*ast.TypeSpec).Type) != reflect.TypeOf(&ast.StructType{}) {
+	structName := fmt.Sprintf("%v", spec.(*ast.TypeSpec).Name)
+	structKey := getStructKey(getPackagePath(file), structName)
+	explainedName := fmt.Sprintf("%v.%v", file.Node.Name.Name, structName)
+	position := getPosition(file.Fset, spec.(*ast.TypeSpec).Name.Pos())
+
+	if reflect.TypeOf(spec.(*ast.TypeSpec).Type) != reflect.TypeOf(&ast.StructType{}) {
+		s.explainStruct(position, domain.StructExplanation{Name: explainedName, Reason: "not a struct"})
+		return tableDetails, false
+	}
+
+	// generic structs are only used as tables when instantiated (e.g. embedded as `Entity[int64]`).
+	if spec.(*ast.TypeSpec).TypeParams != nil {
+		s.explainStruct(position, domain.StructExplanation{Name: explainedName, Reason: "generic struct, used only when instantiated"})
 		return tableDetails, false
 	}
 
-	structDecl := typeSpec[0].(*ast.TypeSpec).Type.(*ast.StructType)
-	structName := fmt.Sprintf("%v", typeSpec[0].(*ast.TypeSpec).Name)
+	structDecl := spec.(*ast.TypeSpec).Type.(*ast.StructType)
+
+	// structs embedded in other structs are flattened into them instead of being tables on their own.
+	if s.embeddedStructs[structKey] {
+		s.explainStruct(position, domain.StructExplanation{Name: explainedName, Reason: "embedded into other structs"})
+		return tableDetails, false
+	}
 
-	columnList := s.getTagFieldsFromStruct(tagRegexp, structDecl.Fields.List)
+	s.fieldExplanations = []domain.ColumnExplanation{}
+	declaredFields := getFieldsFromSyntax(file, structDecl)
+	for idx := range declaredFields {
+		declaredFields[idx].structName = structName
+	}
+	dialect := s.getDialect(declaredFields)
+	fields := s.flattenFields(dialect, declaredFields, "", map[string]bool{})
+
+	columnList := s.getTagFieldsFromStruct(dialect, fields)
 	if len(columnList) == 0 {
+		s.explainStruct(position, domain.StructExplanation{
+			Name:    explainedName,
+			Dialect: dialect.Name(),
+			Reason:  "no fields mapped to columns",
+			Columns: s.fieldExplanations,
+		})
 		return tableDetails, false
 	}
 
 	tableDetails = domain.Table{
-		Name:       s.util.GetCaseOfString(structName, s.options.TableNameCase),
+		Name:       s.getTableName(structKey),
 		ColumnList: columnList,
+		Source:     getSource(position, structName, ""),
+	}
+
+	if s.options.Descriptions {
+		tableDetails.Description = getDescription(doc)
 	}
 
+	s.collectAssociations(dialect, structKey, tableDetails.Name, fields)
+
+	s.explainStruct(position, domain.StructExplanation{
+		Name:    explainedName,
+		IsTable: true,
+		Table:   tableDetails.Name,
+		Dialect: dialect.Name(),
+		Reason:  "fields mapped to columns",
+		Columns: s.fieldExplanations,
+	})
+
 	return tableDetails, true
 }
 
-// getTagFieldsFromStruct retrieves and returns the values that exist on a respective tag.
-func (s *Service) getTagFieldsFromStruct(tagRegexp *regexp.Regexp, fields []*ast.Field) []domain.Column {
-	var columns []domain.Column
-	pkFound := false
+// getTableName returns the name of the table of a struct, either returned by its `TableName()` method, declared in
+// its tags or derived from the name of the struct.
+func (s *Service) getTableName(structKey string) string {
+	if tableName, found := s.tableNames[structKey]; found {
+		return tableName
+	}
+
+	if def, found := s.structIndex[structKey]; found {
+		declaredFields := getFieldsFromSyntax(def.file, def.structType)
+		if tableName, found := getDeclaredTableName(s.getDialect(declaredFields), declaredFields); found {
+			return tableName
+		}
+	}
+
+	return s.util.GetCaseOfString(getStructName(structKey), s.options.TableNameCase)
+}
+
+// getDialect returns the dialect to interpret the tags of a struct with, either the one provided in the options or
+// the one detected from the tags of its fields.
+func (s *Service) getDialect(fields []structField) tag.Dialect {
+	if s.options.Tag != tagAuto {
+		return s.dialects.Get(s.options.Tag)
+	}
+
+	var structTags []string
 	for _, field := range fields {
-		columnName := ""
-		if field.Tag != nil && len(field.Tag.Value) > 0 {
-			match := tagRegexp.FindStringSubmatch(field.Tag.Value)
-			if len(match) < 2 {
-				continue
-			}
+		structTags = append(structTags, field.tag)
+	}
+
+	dialect, found := s.dialects.Detect(structTags)
+	if !found {
+		return s.dialects.Get(defaultTag)
+	}
 
-			columnName = match[1]
+	return dialect
+}
+
+// getDeclaredTableName returns the name of the table, if declared in the tag of an embedded field of the struct
+// (e.g. `bun:"table:users"` on bun.BaseModel).
+func getDeclaredTableName(dialect tag.Dialect, fields []structField) (string, bool) {
+	for _, field := range fields {
+		if !field.embedded {
+			continue
 		}
 
-		if len(columnName) == 0 {
+		value, found := reflect.StructTag(field.tag).Lookup(dialect.Key())
+		if !found {
 			continue
 		}
 
-		newCol := domain.Column{
-			Name:         columnName,
-			Type:         s.util.GetDBDataTypeFromCodeDataType(fmt.Sprintf("%v", field.Type)),
-			IsPrimaryKey: s.options.IDField == columnName,
-			IsForeignKey: false,
-			IsExtraField: false,
+		if tableName, found := dialect.ParseTable(value); found {
+			return tableName, true
+		}
+	}
+
+	return "", false
+}
+
+// getTagFieldsFromStruct retrieves and returns the values that exist on a respective tag.
+//
+// The primary key of each table is the one declared in the tags (with several columns if composite), otherwise the
+// column named after the id field. When there is none, the id field is added as primary key only if requested.
+func (s *Service) getTagFieldsFromStruct(dialect tag.Dialect, fields []structField) []domain.Column {
+	var columns []domain.Column
+	var fieldNames []string
+	pkFound := false
+	for _, field := range fields {
+		newCol, found := s.getColumn(dialect, field)
+		if !found {
+			continue
 		}
+
 		columns = append(columns, newCol)
+		fieldNames = append(fieldNames, field.name)
 		pkFound = pkFound || newCol.IsPrimaryKey
 	}
 
@@ -300,15 +516,39 @@ func (s *Service) getTagFieldsFromStruct(tagRegexp *regexp.Regexp, fields []*ast
 		return []domain.Column{}
 	}
 
-	if !pkFound {
+	// without a primary key declared in the tags, the primary key is the field by the convention of the dialect
+	// (e.g. `ID` in gorm) or else the column named after the id field.
+	for idx := range columns {
+		if !pkFound && dialect.PrimaryKeyField() != "" && fieldNames[idx] == dialect.PrimaryKeyField() {
+			columns[idx].IsPrimaryKey, columns[idx].IsNullable = true, false
+			pkFound = true
+		}
+	}
+
+	for idx := range columns {
+		if !pkFound && s.options.IDField != "" && columns[idx].Name == s.options.IDField {
+			columns[idx].IsPrimaryKey, columns[idx].IsNullable = true, false
+			pkFound = true
+		}
+	}
+
+	s.markForeignKeys(dialect, fields, columns)
+
+	if !pkFound && s.options.SyntheticIDField {
+		idField := s.options.IDField
+		if idField == "" {
+			idField = defaultIDField
+		}
+
 		pkColumn := domain.Column{
-			Name:         s.options.IDField,
-			Type:         "integer",
+			Name:         idField,
+			Type:         columnTypeInteger,
 			IsPrimaryKey: true,
 			IsForeignKey: false,
 			IsExtraField: false,
 		}
 		columns = append(columns, pkColumn)
+		s.explainField(structField{}, "", idField, columnTypeInteger, "synthetic primary key, since none is declared")
 	}
 
 	if len(s.options.CommonFields.Value()) > 0 {
@@ -327,32 +567,268 @@ func (s *Service) getTagFieldsFromStruct(tagRegexp *regexp.Regexp, fields []*ast
 	return columns
 }
 
-// enrichForeignKeyReferences enriches the references between tables in the diagram.
+// getColumn retrieves the column of a field based on the value of its tag, as interpreted by the provided dialect.
+//
+// Depending on the dialect, the exported fields without a tag are columns as well (e.g. in gorm), unless they
+// describe an association to another model.
+func (s *Service) getColumn(dialect tag.Dialect, field structField) (domain.Column, bool) {
+	if field.embedded {
+		return s.skipField(field, "embedded field, declaring the table of the model")
+	}
+
+	value, found := reflect.StructTag(field.tag).Lookup(dialect.Key())
+	if !found && !ast.IsExported(field.name) {
+		return s.skipField(field, "unexported field without a tag")
+	}
+
+	if !found && !dialect.IncludesUntaggedFields() {
+		return s.skipField(field, fmt.Sprintf("no %v tag", dialect.Key()))
+	}
+
+	definition := dialect.ParseColumn(value)
+	if definition.Ignore {
+		return s.skipField(field, "ignored by the tag")
+	}
+
+	if definition.Relation != "" {
+		return s.skipField(field, fmt.Sprintf("%v relation declared by the tag", definition.Relation))
+	}
+
+	// the relations declared explicitly override the ones declared through the dialect.
+	if relationValue, found := reflect.StructTag(field.tag).Lookup(tag.RelationKey); found {
+		relation := tag.ParseRelation(relationValue)
+		if relation.ReferencedTable != "" {
+			definition.ReferencedTable, definition.ReferencedColumn = relation.ReferencedTable, relation.ReferencedColumn
+		}
+		if relation.Cardinality != "" {
+			definition.Cardinality = relation.Cardinality
+		}
+	}
+
+	if model, isAssociation := s.getReferencedModel(field); isAssociation {
+		return s.skipField(field, fmt.Sprintf("association to %v", model))
+	}
+
+	columnName := definition.Name
+	if columnName == "" {
+		columnName = s.util.GetCaseOfString(field.name, s.options.ColumnNameCase)
+	}
+	columnName = field.columnPrefix + columnName
+
+	dataType, isNullableType, mappedType := s.getColumnType(field)
+
+	columnType := definition.Type
+	reason := "type declared by the tag"
+	if columnType == "" {
+		columnType = getSizedDataType(dataType, definition.Size)
+		reason = fmt.Sprintf("type mapped from %v", mappedType)
+	}
+
+	isPrimaryKey := definition.IsPrimaryKey
+
+	s.explainField(field, mappedType, columnName, columnType, reason)
+
+	return domain.Column{
+		Name:             columnName,
+		Type:             columnType,
+		IsPrimaryKey:     isPrimaryKey,
+		IsForeignKey:     definition.ReferencedTable != "",
+		IsExtraField:     false,
+		IsUnique:         definition.IsUnique,
+		IsNullable:       (definition.IsNullable || isNullableType) && !definition.IsNotNull && !isPrimaryKey,
+		Default:          definition.Default,
+		ReferencedTable:  definition.ReferencedTable,
+		ReferencedColumn: definition.ReferencedColumn,
+		Cardinality:      definition.Cardinality,
+		Description:      s.getFieldDescription(field),
+		Source:           getSource(field.position, field.structName, field.name),
+	}, true
+}
+
+// getFieldDescription returns the description of the column of a field, if the descriptions are requested.
+func (s *Service) getFieldDescription(field structField) string {
+	if !s.options.Descriptions {
+		return ""
+	}
+
+	return field.description
+}
+
+// getSource returns the source of a table or a column, if its position in the code is known.
+func getSource(position token.Position, structName, fieldName string) *domain.Source {
+	if !position.IsValid() {
+		return nil
+	}
+
+	return &domain.Source{
+		Filename: position.Filename,
+		Line:     position.Line,
+		Column:   position.Column,
+		Struct:   structName,
+		Field:    fieldName,
+	}
+}
+
+// markForeignKeys marks as foreign keys the columns that the association fields declare as their foreign key
+// (e.g. `gorm:"foreignKey:CreatorID;references:ID"` or `bun:"rel:belongs-to,join:creator_id=id"`), referring to the
+// table of the associated model.
+func (s *Service) markForeignKeys(dialect tag.Dialect, fields []structField, columns []domain.Column) {
+	for _, field := range fields {
+		value, found := reflect.StructTag(field.tag).Lookup(dialect.Key())
+		if !found {
+			continue
+		}
+
+		definition := dialect.ParseColumn(value)
+		if definition.ForeignKey == "" {
+			continue
+		}
+
+		if definition.Relation != "" && definition.Relation != tag.RelationBelongsTo {
+			continue
+		}
+
+		referencedModel, isAssociation := s.getReferencedModel(field)
+		if !isAssociation {
+			continue
+		}
+
+		// the columns of a composite foreign key (e.g. `gorm:"foreignKey:OrderID,LineNo"`) refer to the columns of the
+		// same position in the references, grouped by the name of the association field.
+		foreignKeys := strings.Split(definition.ForeignKey, ",")
+		references := strings.Split(definition.References, ",")
+		foreignKeyName := ""
+		if len(foreignKeys) > 1 {
+			foreignKeyName = field.name
+		}
+
+		for position, foreignKey := range foreignKeys {
+			referencedColumn := ""
+			if position < len(references) && references[position] != "" {
+				referencedColumn = s.util.GetCaseOfString(strings.TrimSpace(references[position]), s.options.ColumnNameCase)
+			}
+
+			foreignKey = field.columnPrefix + s.util.GetCaseOfString(strings.TrimSpace(foreignKey), s.options.ColumnNameCase)
+			for idx := range columns {
+				if columns[idx].Name != foreignKey || columns[idx].ReferencedTable != "" ||
+					(columns[idx].IsPrimaryKey && foreignKeyName == "") {
+					continue
+				}
+
+				columns[idx].IsForeignKey = true
+				columns[idx].ReferencedTable = s.getTableName(referencedModel)
+				columns[idx].ReferencedColumn = referencedColumn
+				columns[idx].ForeignKeyName = foreignKeyName
+			}
+		}
+	}
+}
+
+// enrichForeignKeyReferences finds the references between the tables, either declared, described by association fields
+// or inferred by the rules of the inference engine, and marks the respective columns as foreign keys.
 func (s *Service) enrichForeignKeyReferences(diagram *domain.Diagram) {
+	associationReferences := s.getAssociationReferences(diagram)
+
+	inferredReferences := s.inferReferences(diagram, associationReferences)
 	for idx := range diagram.TableList {
-		diagram.ReferenceList = append(diagram.ReferenceList, s.getReferencesToTable(diagram, diagram.TableList[idx].Name)...)
+		diagram.ReferenceList = append(diagram.ReferenceList, getReferencesToTable(diagram, diagram.TableList[idx].Name, inferredReferences)...)
 	}
+	diagram.ReferenceList = append(diagram.ReferenceList, getDeclaredReferences(diagram)...)
+	diagram.ReferenceList = append(diagram.ReferenceList, associationReferences...)
+
+	s.setTypesOfReferences(diagram)
 }
 
-// getReferencesToTable finds and returns a list of all the references to a table.
-func (s *Service) getReferencesToTable(diagram *domain.Diagram, searchForTable string) []domain.Reference {
+// getDeclaredReferences returns the references of the columns that declare the table they refer to. The columns of a
+// composite foreign key are combined into a single reference.
+func getDeclaredReferences(diagram *domain.Diagram) []domain.Reference {
 	var referenceList []domain.Reference
-	for idxTb := range diagram.TableList {
-		if diagram.TableList[idxTb].Name == searchForTable {
-			continue
+	for _, table := range diagram.TableList {
+		composite := map[string]int{}
+		for _, column := range table.ColumnList {
+			if column.ReferencedTable == "" {
+				continue
+			}
+
+			if idx, found := composite[column.ForeignKeyName]; found {
+				reference := &referenceList[idx]
+				reference.FromTableColumns = append(reference.FromTableColumns, column.Name)
+				reference.ToTableColumns = append(reference.ToTableColumns, column.ReferencedColumn)
+				reference.FromTableColumn = strings.Join(reference.FromTableColumns, ", ")
+				reference.ToTableColumn = strings.Join(reference.ToTableColumns, ", ")
+				continue
+			}
+
+			reference := domain.Reference{
+				FromTableName:   table.Name,
+				FromTableColumn: column.Name,
+				ToTableName:     column.ReferencedTable,
+				ToTableColumn:   column.ReferencedColumn,
+				Cardinality:     column.Cardinality,
+				Rule:            ruleDeclared,
+			}
+
+			if column.ForeignKeyName != "" {
+				composite[column.ForeignKeyName] = len(referenceList)
+				reference.FromTableColumns = []string{column.Name}
+				reference.ToTableColumns = []string{column.ReferencedColumn}
+			}
+
+			referenceList = append(referenceList, reference)
+		}
+	}
+
+	return referenceList
+}
+
+// inferReferences infers the table that each column refers to (if any), indexed by the table and the column name.
+// The columns declaring the table they refer to, or referring to a table through an association, are not inferred.
+func (s *Service) inferReferences(diagram *domain.Diagram, associationReferences []domain.Reference) map[string]inference.Reference {
+	var tableNames []string
+	for _, table := range diagram.TableList {
+		tableNames = append(tableNames, table.Name)
+	}
+
+	associated := map[string]bool{}
+	for _, reference := range associationReferences {
+		associated[getReferenceKey(reference)] = true
+	}
+
+	inferredReferences := map[string]inference.Reference{}
+	for _, table := range diagram.TableList {
+		for _, column := range table.ColumnList {
+			if column.ReferencedTable != "" || associated[fmt.Sprintf("%v.%v", table.Name, column.Name)] {
+				continue
+			}
+
+			if reference, found := s.inference.Infer(table.Name, column.Name, tableNames); found {
+				inferredReferences[fmt.Sprintf("%v.%v", table.Name, column.Name)] = reference
+			}
 		}
+	}
 
+	return inferredReferences
+}
+
+// getReferencesToTable returns a list of all the inferred references to a table, marking the columns as foreign keys.
+func getReferencesToTable(diagram *domain.Diagram, searchForTable string, inferredReferences map[string]inference.Reference) []domain.Reference {
+	var referenceList []domain.Reference
+	for idxTb := range diagram.TableList {
 		for idxCol := range diagram.TableList[idxTb].ColumnList {
-			if strings.Contains(diagram.TableList[idxTb].ColumnList[idxCol].Name, searchForTable) || strings.Contains(diagram.TableList[idxTb].ColumnList[idxCol].Name, s.util.GetValueCount(s.options.TableNamePlural, searchForTable)) {
-				newReference := domain.Reference{
-					FromTableName:   diagram.TableList[idxTb].Name,
-					FromTableColumn: diagram.TableList[idxTb].ColumnList[idxCol].Name,
-					ToTableName:     searchForTable,
-					TypeOfReference: "*--*",
-				}
-				referenceList = append(referenceList, newReference)
-				diagram.TableList[idxTb].ColumnList[idxCol].IsForeignKey = true
+			column := &diagram.TableList[idxTb].ColumnList[idxCol]
+
+			reference, found := inferredReferences[fmt.Sprintf("%v.%v", diagram.TableList[idxTb].Name, column.Name)]
+			if !found || reference.Table != searchForTable {
+				continue
 			}
+
+			referenceList = append(referenceList, domain.Reference{
+				FromTableName:   diagram.TableList[idxTb].Name,
+				FromTableColumn: column.Name,
+				ToTableName:     searchForTable,
+				Rule:            reference.Rule,
+			})
+			column.IsForeignKey = true
 		}
 	}
 
@@ -385,8 +861,3 @@ func defineFilesToParse(directory string, filesList []string) []string {
 
 	return filesToParse
 }
-
-// getTagRegex prepares and returns the regexp for getting the value of a tag.
-func getTagRegexp(tag string) *regexp.Regexp {
-	return regexp.MustCompile(fmt.Sprintf("%v:\"(.*?)\"", tag))
-}
@@ -1,15 +1,19 @@
 package service_test
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/eujoy/erbuilder/internal/app/service"
 	"github.com/eujoy/erbuilder/internal/config"
 	"github.com/eujoy/erbuilder/internal/domain"
+	"github.com/eujoy/erbuilder/internal/pkg/introspect"
+	"github.com/eujoy/erbuilder/internal/pkg/loader"
 	"github.com/eujoy/erbuilder/internal/pkg/util"
 	"github.com/eujoy/erbuilder/internal/pkg/writer"
 	"github.com/eujoy/erbuilder/test/mock"
@@ -19,7 +23,7 @@ import (
 
 func TestNew(t *testing.T) {
 	options := domain.Options{}
-	actualService := service.New(options, &mock.Survey{}, util.New(), writer.New(util.New(), "some/path", "some_filename"))
+	actualService := service.New(options, loader.New(), &mock.Survey{}, util.New(), writer.New(util.New(), "some/path", "some_filename"))
 
 	if reflect.TypeOf(&service.Service{}) != reflect.TypeOf(actualService) {
 		t.Errorf("Expected to get type '%v' but got '%v'.", reflect.TypeOf(&service.Service{}), reflect.TypeOf(actualService))
@@ -57,6 +61,7 @@ func TestGenerate(t *testing.T) {
 				testOptions := options
 				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
 				testOptions.FileList = fileListStringSlice
+				testOptions.SyntheticIDField = true
 				return testOptions
 			}("normal-execution-with-provided-list-of-files"),
 			filenameSuffix:     "normal-execution-with-provided-list-of-files",
@@ -67,6 +72,7 @@ func TestGenerate(t *testing.T) {
 			providedOptions: func(filenameSuffix string) domain.Options {
 				testOptions := options
 				testOptions.Directory = "./../../../test"
+				testOptions.SyntheticIDField = true
 				return testOptions
 			}("normal-execution-with-directory-provided"),
 			filenameSuffix:     "normal-execution-with-directory-provided",
@@ -87,6 +93,7 @@ func TestGenerate(t *testing.T) {
 				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
 				testOptions.Directory = "./../../../test"
 				testOptions.CommonFields = commonFieldsStringSlice
+				testOptions.SyntheticIDField = true
 				return testOptions
 			}("normal-execution-with-directory-provided-and-common-fields"),
 			filenameSuffix:     "normal-execution-with-directory-provided-and-common-fields",
@@ -99,11 +106,534 @@ func TestGenerate(t *testing.T) {
 				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
 				testOptions.Directory = "./../../../test"
 				testOptions.ExtraTablesDefinition = `[{"name":"schema_migrations","columns":[{"name":"id","type":"integer","is_primary_key":true,"is_foreign_key":false,"is_extra_field":false},{"name":"version","type":"varchar","is_primary_key":false,"is_foreign_key":false,"is_extra_field":false}],"color":"#ebe486"}]`
+				testOptions.SyntheticIDField = true
 				return testOptions
 			}("include-extra-tables-definition"),
 			filenameSuffix:     "include-extra-tables-definition",
 			expectedOutputFile: "./../../../test/example-er-diagram-with-extra-tables.er",
 		},
+		"Generate .er file from packages loaded with their type information": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				packagesStringSlice := cli.StringSlice{}
+				err := packagesStringSlice.Set("./../../../testdata/typed/...")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Packages = packagesStringSlice
+				return testOptions
+			}("typed-packages"),
+			filenameSuffix:     "typed-packages",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-typed-packages.er",
+		},
+		"Generate .er file from a directory with embedded structs": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/embedded"
+				return testOptions
+			}("embedded-structs-from-directory"),
+			filenameSuffix:     "embedded-structs-from-directory",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-embedded-structs.er",
+		},
+		"Generate .er file from a package embedding structs of a package that is not loaded": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				packagesStringSlice := cli.StringSlice{}
+				err := packagesStringSlice.Set("./../../../testdata/embedded/models")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Packages = packagesStringSlice
+				return testOptions
+			}("embedded-structs-from-package"),
+			filenameSuffix:     "embedded-structs-from-package",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-embedded-structs.er",
+		},
+		"Generate .er file from a directory with models embedded into other models": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/embeddedmodels"
+				return testOptions
+			}("embedded-models"),
+			filenameSuffix:     "embedded-models",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-embedded-models.er",
+		},
+		"Generate .er file from a directory with packages sharing the same name": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/samename"
+				return testOptions
+			}("same-package-names-from-directory"),
+			filenameSuffix:     "same-package-names-from-directory",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-same-package-names.er",
+		},
+		"Generate .er file from packages sharing the same name": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				packagesStringSlice := cli.StringSlice{}
+				err := packagesStringSlice.Set("./../../../testdata/samename/...")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Packages = packagesStringSlice
+				return testOptions
+			}("same-package-names-from-package"),
+			filenameSuffix:     "same-package-names-from-package",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-same-package-names.er",
+		},
+		"Generate .er file from a directory with gorm tags": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/gorm"
+				testOptions.Tag = "gorm"
+				return testOptions
+			}("gorm-tags"),
+			filenameSuffix:     "gorm-tags",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-gorm-tags.er",
+		},
+		"Generate .er file from a directory with gorm tags declaring sizes for the postgres dialect": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/gorm"
+				testOptions.Tag = "gorm"
+				testOptions.Dialect = "postgres"
+				return testOptions
+			}("gorm-tags-postgres"),
+			filenameSuffix:     "gorm-tags-postgres",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-gorm-tags-for-postgres.er",
+		},
+		"Generate .er file from a directory with models embedding the gorm model through db tags": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/gormmodel"
+				return testOptions
+			}("gorm-model-with-db-tags"),
+			filenameSuffix:     "gorm-model-with-db-tags",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-gorm-model-and-db-tags.er",
+		},
+		"Generate .er file from a directory with db tag options": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/sqlx"
+				return testOptions
+			}("db-tag-options"),
+			filenameSuffix:     "db-tag-options",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-db-tag-options.er",
+		},
+		"Generate .er file from a directory with the tag dialect detected per struct": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/dialects"
+				testOptions.Tag = "auto"
+				return testOptions
+			}("mixed-dialects"),
+			filenameSuffix:     "mixed-dialects",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-mixed-dialects.er",
+		},
+		"Generate .er file from a directory with the ent dialect chosen explicitly": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/ent"
+				testOptions.Tag = "ent"
+				return testOptions
+			}("ent-dialect"),
+			filenameSuffix:     "ent-dialect",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-ent-dialect.er",
+		},
+		"Generate .er file from a package with structs overriding their table name": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				packagesStringSlice := cli.StringSlice{}
+				err := packagesStringSlice.Set("./../../../testdata/tablename")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Packages = packagesStringSlice
+				return testOptions
+			}("table-name-methods"),
+			filenameSuffix:     "table-name-methods",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-table-name-methods.er",
+		},
+		"Generate .er file from a directory with grouped, anonymous and generic structs": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/grouped"
+				return testOptions
+			}("grouped-structs-from-directory"),
+			filenameSuffix:     "grouped-structs-from-directory",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-grouped-and-generic-structs.er",
+		},
+		"Generate .er file from a package with grouped, anonymous and generic structs": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				packagesStringSlice := cli.StringSlice{}
+				err := packagesStringSlice.Set("./../../../testdata/grouped")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Packages = packagesStringSlice
+				return testOptions
+			}("grouped-structs-from-package"),
+			filenameSuffix:     "grouped-structs-from-package",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-grouped-and-generic-structs.er",
+		},
+		"Generate .er file from a directory with nullable fields": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/nullable"
+				return testOptions
+			}("nullable-fields-from-directory"),
+			filenameSuffix:     "nullable-fields-from-directory",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-nullable-fields.er",
+		},
+		"Generate .er file from a package with nullable fields": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				packagesStringSlice := cli.StringSlice{}
+				err := packagesStringSlice.Set("./../../../testdata/nullable")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Packages = packagesStringSlice
+				return testOptions
+			}("nullable-fields-from-package"),
+			filenameSuffix:     "nullable-fields-from-package",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-nullable-fields.er",
+		},
+		"Generate .er file from a directory with commonly used data types": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/datatypes"
+				return testOptions
+			}("data-types-from-directory"),
+			filenameSuffix:     "data-types-from-directory",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-data-types-from-directory.er",
+		},
+		"Generate .er file from a package with commonly used data types": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				packagesStringSlice := cli.StringSlice{}
+				err := packagesStringSlice.Set("./../../../testdata/datatypes/...")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Packages = packagesStringSlice
+				return testOptions
+			}("data-types-from-package"),
+			filenameSuffix:     "data-types-from-package",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-data-types.er",
+		},
+		"Generate .er file from a package with a data type mapping file": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				packagesStringSlice := cli.StringSlice{}
+				err := packagesStringSlice.Set("./../../../testdata/datatypes/...")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Packages = packagesStringSlice
+				testOptions.TypeMappingFile = "./../../../testdata/datatypes/mapping.json"
+				return testOptions
+			}("data-type-mapping"),
+			filenameSuffix:     "data-type-mapping",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-data-type-mapping.er",
+		},
+		"Generate .er file from a package with the postgres dialect": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				packagesStringSlice := cli.StringSlice{}
+				err := packagesStringSlice.Set("./../../../testdata/datatypes/...")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Packages = packagesStringSlice
+				testOptions.Dialect = "postgres"
+				return testOptions
+			}("postgres-dialect"),
+			filenameSuffix:     "postgres-dialect",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-postgres-dialect.er",
+		},
+		"Generate .er file from a package with the sqlite dialect": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				packagesStringSlice := cli.StringSlice{}
+				err := packagesStringSlice.Set("./../../../testdata/datatypes/...")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Packages = packagesStringSlice
+				testOptions.Dialect = "sqlite"
+				return testOptions
+			}("sqlite-dialect"),
+			filenameSuffix:     "sqlite-dialect",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-sqlite-dialect.er",
+		},
+		"Generate .er file from a directory with foreign keys inferred by the default rules": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/inference"
+				return testOptions
+			}("inferred-foreign-keys"),
+			filenameSuffix:     "inferred-foreign-keys",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-inferred-foreign-keys.er",
+		},
+		"Generate .er file from a directory with foreign keys inferred by allow and deny lists": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				allowListStringSlice := cli.StringSlice{}
+				err := allowListStringSlice.Set("session.owner=user")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				denyListStringSlice := cli.StringSlice{}
+				err = denyListStringSlice.Set("session.user_role_id")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/inference"
+				testOptions.ForeignKeyAllowList = allowListStringSlice
+				testOptions.ForeignKeyDenyList = denyListStringSlice
+				return testOptions
+			}("foreign-key-allow-and-deny-lists"),
+			filenameSuffix:     "foreign-key-allow-and-deny-lists",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-foreign-key-allow-and-deny-lists.er",
+		},
+		"Generate .er file from a directory with relations declared explicitly": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/relations"
+				return testOptions
+			}("explicit-relations"),
+			filenameSuffix:     "explicit-relations",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-explicit-relations.er",
+		},
+		"Generate .er file from a directory with the cardinality of the relations inferred": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/cardinality"
+				return testOptions
+			}("inferred-cardinality"),
+			filenameSuffix:     "inferred-cardinality",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-inferred-cardinality.er",
+		},
+		"Generate .er file from a directory with the relations inferred from the association fields": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/associations"
+				testOptions.Tag = "gorm"
+				return testOptions
+			}("association-relations"),
+			filenameSuffix:     "association-relations",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-association-relations.er",
+		},
+		"Generate .er file from a directory with self references and several references to the same table": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/selfreference"
+				return testOptions
+			}("self-references"),
+			filenameSuffix:     "self-references",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-self-references.er",
+		},
+		"Generate .er file from a directory with composite primary and foreign keys": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/composite"
+				testOptions.Tag = "gorm"
+				return testOptions
+			}("composite-keys"),
+			filenameSuffix:     "composite-keys",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-composite-keys.er",
+		},
+		"Generate .er file from a directory with the id field added to the tables without primary key": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/composite"
+				testOptions.Tag = "gorm"
+				testOptions.SyntheticIDField = true
+				return testOptions
+			}("synthetic-id-field"),
+			filenameSuffix:     "synthetic-id-field",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-synthetic-id-field.er",
+		},
+		"Generate .er file from a directory with the junction tables kept": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/junctions"
+				testOptions.JunctionTables = "keep"
+				return testOptions
+			}("junction-tables-kept"),
+			filenameSuffix:     "junction-tables-kept",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-junction-tables-kept.er",
+		},
+		"Generate .er file from a directory with the junction tables collapsed": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/junctions"
+				testOptions.JunctionTables = "collapse"
+				return testOptions
+			}("junction-tables-collapsed"),
+			filenameSuffix:     "junction-tables-collapsed",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-junction-tables-collapsed.er",
+		},
+		"Generate .er file from a directory with the comments as descriptions": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Directory = "./../../../testdata/descriptions"
+				testOptions.Descriptions = true
+				testOptions.ExtraTablesDefinition = `[{"name":"schema_migrations","description":"Versions of the applied migrations.","columns":[{"name":"version","type":"varchar","is_primary_key":true,"description":"Version of the migration."}]}]`
+				return testOptions
+			}("descriptions"),
+			filenameSuffix:     "descriptions",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-descriptions.er",
+		},
+		"Generate .er file from sql files": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				sqlFilesStringSlice := cli.StringSlice{}
+				err := sqlFilesStringSlice.Set("./../../../testdata/ddl/schema.sql")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.SQLFiles = sqlFilesStringSlice
+				testOptions.Descriptions = true
+				return testOptions
+			}("sql-files"),
+			filenameSuffix:     "sql-files",
+			expectedOutputFile: "./../../../test/example-er-diagram-from-sql-files.er",
+		},
+		"Generate .er file from a migrations directory": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Migrations = "./../../../testdata/migrations/golang-migrate"
+				return testOptions
+			}("migrations"),
+			filenameSuffix:     "migrations",
+			expectedOutputFile: "./../../../test/example-er-diagram-from-migrations.er",
+		},
+		"Generate .er file from a migrations directory at an intermediate migration version": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.Migrations = "./../../../testdata/migrations/golang-migrate"
+				testOptions.MigrationVersion = "2"
+				return testOptions
+			}("migration-version"),
+			filenameSuffix:     "migration-version",
+			expectedOutputFile: "./../../../test/example-er-diagram-from-migrations-at-version.er",
+		},
+		"Generate .er file from a sqlite database": {
+			setupFn: defaultGenerateTestSetupFunc,
+			providedOptions: func(filenameSuffix string) domain.Options {
+				schema, err := os.ReadFile("./../../../testdata/sqlite/schema.sql")
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				databasePath := filepath.Join(t.TempDir(), "test.db")
+				db, err := sql.Open(introspect.SQLiteDriver, databasePath)
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+				defer db.Close()
+
+				_, err = db.Exec(string(schema))
+				if err != nil {
+					t.Errorf("Expected to get nil as error but got '%v'.", err)
+				}
+
+				testOptions := options
+				testOptions.OutputFilename = fmt.Sprintf("test-er-diagram-%v", filenameSuffix)
+				testOptions.SQLite = databasePath
+				return testOptions
+			}("sqlite"),
+			filenameSuffix:     "sqlite",
+			expectedOutputFile: "./../../../test/example-er-diagram-from-sqlite.er",
+		},
 	}
 
 	for name, tc := range testCases {
@@ -115,7 +645,7 @@ func TestGenerate(t *testing.T) {
 }
 
 func defaultGenerateTestSetupFunc(options domain.Options) *service.Service {
-	return service.New(options, nil, util.New(), writer.New(util.New(), options.OutputPath, options.OutputFilename))
+	return service.New(options, loader.New(), nil, util.New(), writer.New(util.New(), options.OutputPath, options.OutputFilename))
 }
 
 func validateGenerateExecution(t *testing.T, actualService *service.Service, actualOutputFileName, expectedOutputFile string) {
@@ -140,6 +670,198 @@ func validateGenerateExecution(t *testing.T, actualService *service.Service, act
 	}
 }
 
+func TestExplain(t *testing.T) {
+	options := domain.Options{
+		IDField:        "id",
+		Tag:            "db",
+		ColumnNameCase: "snake_case",
+		TableNameCase:  "snake_case",
+		Config:         config.New(),
+		Directory:      "./../../../testdata/explain",
+	}
+
+	testCases := map[string]struct {
+		explainFormat      string
+		expectedOutputFile string
+	}{
+		"Explain the structs, columns and references of a directory as text": {
+			explainFormat:      "text",
+			expectedOutputFile: "./../../../test/example-explanation.txt",
+		},
+		"Explain the structs, columns and references of a directory as json": {
+			explainFormat:      "json",
+			expectedOutputFile: "./../../../test/example-explanation.json",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testOptions := options
+			testOptions.ExplainFormat = tc.explainFormat
+
+			actualExplanation, err := service.New(testOptions, loader.New(), nil, util.New(), nil).Explain()
+			if err != nil {
+				t.Errorf("Expected to get nil as error but got '%v'.", err)
+			}
+
+			expectedExplanation, err := os.ReadFile(tc.expectedOutputFile)
+			if err != nil {
+				t.Errorf("Expected to get nil as error when reading the example test file but got '%v'.", err)
+			}
+
+			if string(expectedExplanation) != actualExplanation {
+				t.Errorf("Expected to get '%v' as explanation but got '%v'.", string(expectedExplanation), actualExplanation)
+			}
+		})
+	}
+}
+
+func TestDrift(t *testing.T) {
+	options := domain.Options{
+		IDField:        "id",
+		Tag:            "db",
+		ColumnNameCase: "snake_case",
+		TableNameCase:  "snake_case",
+		Config:         config.New(),
+		Directory:      "./../../../testdata/drift/models",
+		Dialect:        "postgres",
+	}
+
+	testCases := map[string]struct {
+		sqlFile            string
+		typeMappingFile    string
+		commonFields       []string
+		explainFormat      string
+		expectedHasDrift   bool
+		expectedOutputFile string
+	}{
+		"Report the differences between the structs and a drifted schema as text": {
+			sqlFile:            "./../../../testdata/drift/schema.sql",
+			explainFormat:      "text",
+			expectedHasDrift:   true,
+			expectedOutputFile: "./../../../test/example-drift.txt",
+		},
+		"Report the differences between the structs and a drifted schema as json": {
+			sqlFile:            "./../../../testdata/drift/schema.sql",
+			explainFormat:      "json",
+			expectedHasDrift:   true,
+			expectedOutputFile: "./../../../test/example-drift.json",
+		},
+		"Report no differences between the structs and a schema in sync": {
+			sqlFile:            "./../../../testdata/drift/in-sync.sql",
+			explainFormat:      "text",
+			expectedHasDrift:   false,
+			expectedOutputFile: "./../../../test/example-drift-in-sync.txt",
+		},
+		"Report no differences for the common fields of the structs in sync with the schema": {
+			sqlFile:            "./../../../testdata/drift/common-fields.sql",
+			commonFields:       []string{"updated_at"},
+			explainFormat:      "text",
+			expectedHasDrift:   false,
+			expectedOutputFile: "./../../../test/example-drift-in-sync.txt",
+		},
+		"Report the differences of the lengths mapped for the structs": {
+			sqlFile:            "./../../../testdata/drift/schema.sql",
+			typeMappingFile:    "./../../../testdata/drift/mapping.json",
+			explainFormat:      "text",
+			expectedHasDrift:   true,
+			expectedOutputFile: "./../../../test/example-drift-with-type-mapping.txt",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testOptions := options
+			testOptions.ExplainFormat = tc.explainFormat
+			testOptions.SQLFiles = *cli.NewStringSlice(tc.sqlFile)
+			testOptions.TypeMappingFile = tc.typeMappingFile
+			testOptions.CommonFields = *cli.NewStringSlice(tc.commonFields...)
+
+			actualReport, actualHasDrift, err := service.New(testOptions, loader.New(), nil, util.New(), nil).Drift()
+			if err != nil {
+				t.Errorf("Expected to get nil as error but got '%v'.", err)
+			}
+
+			if tc.expectedHasDrift != actualHasDrift {
+				t.Errorf("Expected to get '%v' as drift found but got '%v'.", tc.expectedHasDrift, actualHasDrift)
+			}
+
+			expectedReport, err := os.ReadFile(tc.expectedOutputFile)
+			if err != nil {
+				t.Errorf("Expected to get nil as error when reading the example test file but got '%v'.", err)
+			}
+
+			if string(expectedReport) != actualReport {
+				t.Errorf("Expected to get '%v' as report but got '%v'.", string(expectedReport), actualReport)
+			}
+		})
+	}
+}
+
+func TestIntrospect(t *testing.T) {
+	options := domain.Options{
+		Config:     config.New(),
+		OutputPath: "./../../../test",
+		Title:      "example_db",
+	}
+
+	t.Run("Introspect the tables of a sqlite database", func(t *testing.T) {
+		schema, err := os.ReadFile("./../../../testdata/sqlite/schema.sql")
+		if err != nil {
+			t.Errorf("Expected to get nil as error but got '%v'.", err)
+		}
+
+		databasePath := filepath.Join(t.TempDir(), "test.db")
+		db, err := sql.Open(introspect.SQLiteDriver, databasePath)
+		if err != nil {
+			t.Errorf("Expected to get nil as error but got '%v'.", err)
+		}
+		defer db.Close()
+
+		_, err = db.Exec(string(schema))
+		if err != nil {
+			t.Errorf("Expected to get nil as error but got '%v'.", err)
+		}
+
+		testOptions := options
+		testOptions.OutputFilename = "test-er-diagram-introspected-sqlite"
+		testOptions.Driver = introspect.SQLiteDriver
+		testOptions.DSN = databasePath
+
+		actualService := defaultGenerateTestSetupFunc(testOptions)
+		err = actualService.Introspect()
+		if err != nil {
+			t.Errorf("Expected to get nil as error but got '%v'.", err)
+		}
+
+		cmp := equalfile.New(nil, equalfile.Options{})
+		equal, err := cmp.CompareFile("./../../../test/example-er-diagram-from-sqlite.er", "./../../../test/test-er-diagram-introspected-sqlite.er")
+		if err != nil {
+			t.Errorf("Expected to get nil as error but got '%v'.", err)
+		}
+
+		if !equal {
+			t.Errorf("Expected that the generated file and the example test file are the same but they were not.")
+		}
+
+		err = os.Remove("./../../../test/test-er-diagram-introspected-sqlite.er")
+		if err != nil {
+			t.Errorf("Expected to get nil as error when deleting the test file but got '%v'.", err)
+		}
+	})
+
+	t.Run("Attempt to introspect a database of an unsupported driver", func(t *testing.T) {
+		testOptions := options
+		testOptions.Driver = "oracle"
+		testOptions.DSN = "oracle://localhost:1521/db"
+
+		err := defaultGenerateTestSetupFunc(testOptions).Introspect()
+		if err == nil {
+			t.Errorf("Expected to get an error for an unsupported driver but got nil.")
+		}
+	})
+}
+
 func TestBuild(t *testing.T) {
 	defaultTableAnswer := domain.TableAnswer{
 		Name:  "my_table",
@@ -182,7 +904,7 @@ func TestBuild(t *testing.T) {
 					AddMore:      "Nothing",
 				}, expectedError)
 
-				return service.New(options, &mockSurvey, util.New(), nil)
+				return service.New(options, nil, &mockSurvey, util.New(), nil)
 			},
 		},
 		"Failed to get table definition": {
@@ -193,7 +915,7 @@ func TestBuild(t *testing.T) {
 				mockSurvey.On("AskTableDetails").Return(domain.TableAnswer{}, expectedError)
 				mockSurvey.On("AskColumnDetails").Times(0)
 
-				return service.New(options, &mockSurvey, util.New(), nil)
+				return service.New(options, nil, &mockSurvey, util.New(), nil)
 			},
 		},
 		"Failed to get column definition": {
@@ -204,7 +926,7 @@ func TestBuild(t *testing.T) {
 				mockSurvey.On("AskTableDetails").Return(defaultTableAnswer, nil)
 				mockSurvey.On("AskColumnDetails").Return(domain.ColumnAnswer{}, expectedError)
 
-				return service.New(options, &mockSurvey, util.New(), nil)
+				return service.New(options, nil, &mockSurvey, util.New(), nil)
 			},
 		},
 	}
@@ -21,6 +21,10 @@ type author struct {
 type settings struct {
 	AllowedColumnNameCaseValues []string
 	AllowedTableNameCaseValues  []string
+	AllowedDialectValues        []string
+	AllowedDriverValues         []string
+	AllowedJunctionTableValues  []string
+	AllowedExplainFormatValues  []string
 }
 
 // New creates and returns a configuration object for the service.
@@ -40,6 +44,10 @@ func New() Config {
 		Settings: settings{
 			AllowedColumnNameCaseValues: []string{"snake_case", "camelCase", "screaming_snake_case", "kebab_case"},
 			AllowedTableNameCaseValues:  []string{"snake_case", "camelCase", "screaming_snake_case", "kebab_case"},
+			AllowedDialectValues:        []string{"postgres", "mysql", "sqlite"},
+			AllowedDriverValues:         []string{"postgres", "mysql", "sqlite3"},
+			AllowedJunctionTableValues:  []string{"keep", "collapse"},
+			AllowedExplainFormatValues:  []string{"text", "json"},
 		},
 	}
 }
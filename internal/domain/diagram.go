@@ -1,5 +1,7 @@
 package domain
 
+import "fmt"
+
 // Diagram describes the details of the database
 type Diagram struct {
 	Title         string
@@ -12,6 +14,11 @@ type Table struct {
 	Name       string   `json:"name"`
 	ColumnList []Column `json:"columns"`
 	Color      string   `json:"color"`
+
+	// Description describes the table, as documented on its struct.
+	Description string `json:"description,omitempty"`
+
+	Source *Source `json:"source,omitempty"`
 }
 
 // Column describes the details of a column.
@@ -21,6 +28,41 @@ type Column struct {
 	IsPrimaryKey bool   `json:"is_primary_key"`
 	IsForeignKey bool   `json:"is_foreign_key"`
 	IsExtraField bool   `json:"is_extra_field"`
+	IsUnique     bool   `json:"is_unique"`
+	IsNullable   bool   `json:"is_nullable"`
+	Default      string `json:"default"`
+
+	ReferencedTable  string `json:"referenced_table"`
+	ReferencedColumn string `json:"referenced_column"`
+	Cardinality      string `json:"cardinality"`
+
+	// ForeignKeyName groups the columns of a composite foreign key, referring to the same table together.
+	ForeignKeyName string `json:"foreign_key_name"`
+
+	// Description describes the column, as documented on its field.
+	Description string `json:"description,omitempty"`
+
+	Source *Source `json:"source,omitempty"`
+}
+
+// Source describes the position in the code that a table or a column has been retrieved from, alongside with the
+// respective struct and field.
+type Source struct {
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Struct   string `json:"struct"`
+	Field    string `json:"field,omitempty"`
+}
+
+// String returns the position of the source along with the struct and the field (e.g. `models/user.go:42 User.Email`).
+func (s Source) String() string {
+	name := s.Struct
+	if s.Field != "" {
+		name = fmt.Sprintf("%v.%v", s.Struct, s.Field)
+	}
+
+	return fmt.Sprintf("%v:%v %v", s.Filename, s.Line, name)
 }
 
 // Reference describes the references for a table.
@@ -28,5 +70,29 @@ type Reference struct {
 	FromTableName   string
 	FromTableColumn string
 	ToTableName     string
+	ToTableColumn   string
 	TypeOfReference string
+
+	// Cardinality describes the multiplicity of the referencing rows per referenced row (e.g. `1..*`), as declared.
+	Cardinality string
+
+	// Rule describes the way that the reference has been found (e.g. declared in a tag or inferred by the suffix rule).
+	Rule string
+
+	// FromTableColumns and ToTableColumns describe the columns of a composite reference in order, while FromTableColumn
+	// and ToTableColumn list them comma separated.
+	FromTableColumns []string
+	ToTableColumns   []string
+
+	// JunctionTable describes the junction table that a many to many reference has been collapsed from, if any.
+	JunctionTable string
+}
+
+// GetFromTableColumns returns the columns that the reference refers from, either one or several if composite.
+func (r Reference) GetFromTableColumns() []string {
+	if len(r.FromTableColumns) > 0 {
+		return r.FromTableColumns
+	}
+
+	return []string{r.FromTableColumn}
 }
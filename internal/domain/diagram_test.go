@@ -0,0 +1,32 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+)
+
+func TestSourceString(t *testing.T) {
+	testCases := map[string]struct {
+		source         domain.Source
+		expectedOutput string
+	}{
+		"Get the source of a column": {
+			source:         domain.Source{Filename: "models/user.go", Line: 42, Column: 2, Struct: "User", Field: "Email"},
+			expectedOutput: "models/user.go:42 User.Email",
+		},
+		"Get the source of a table": {
+			source:         domain.Source{Filename: "models/user.go", Line: 40, Column: 6, Struct: "User"},
+			expectedOutput: "models/user.go:40 User",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualOutput := tc.source.String()
+			if tc.expectedOutput != actualOutput {
+				t.Errorf("Expected to get '%v' as response but got '%v'.", tc.expectedOutput, actualOutput)
+			}
+		})
+	}
+}
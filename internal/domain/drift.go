@@ -0,0 +1,22 @@
+package domain
+
+// Drift describes the differences found between the tables of the models and the tables of the schema (either parsed
+// from sql files and migrations or read from a database).
+type Drift struct {
+	Differences []Difference `json:"differences"`
+}
+
+// Difference describes a table, a column or a foreign key that differs between the models and the schema.
+type Difference struct {
+	Kind       string `json:"kind"`
+	Table      string `json:"table"`
+	Column     string `json:"column,omitempty"`
+	ModelType  string `json:"model_type,omitempty"`
+	SchemaType string `json:"schema_type,omitempty"`
+
+	// Reference describes the referenced table (and columns) of a foreign key.
+	Reference string `json:"reference,omitempty"`
+
+	// Source describes the position of the struct or the field of the models that differs, if any.
+	Source string `json:"source,omitempty"`
+}
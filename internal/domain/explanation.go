@@ -0,0 +1,42 @@
+package domain
+
+// Explanation describes why each struct of the parsed files became a table (or not), alongside with the decisions
+// taken for its columns and the references found between the tables.
+type Explanation struct {
+	Structs    []StructExplanation    `json:"structs"`
+	References []ReferenceExplanation `json:"references"`
+}
+
+// StructExplanation describes whether a struct became a table and the reason.
+type StructExplanation struct {
+	Name    string              `json:"name"`
+	Source  string              `json:"source,omitempty"`
+	IsTable bool                `json:"is_table"`
+	Table   string              `json:"table,omitempty"`
+	Dialect string              `json:"dialect,omitempty"`
+	Reason  string              `json:"reason"`
+	Columns []ColumnExplanation `json:"columns,omitempty"`
+}
+
+// ColumnExplanation describes whether a field of a struct became a column, alongside with its raw tag and the way
+// that its data type has been resolved.
+type ColumnExplanation struct {
+	Field        string `json:"field"`
+	Source       string `json:"source,omitempty"`
+	Tag          string `json:"tag"`
+	CodeType     string `json:"code_type"`
+	ResolvedType string `json:"resolved_type,omitempty"`
+	IsColumn     bool   `json:"is_column"`
+	Column       string `json:"column,omitempty"`
+	Type         string `json:"type,omitempty"`
+	Reason       string `json:"reason"`
+}
+
+// ReferenceExplanation describes the heuristic (or declaration) that a reference has been found by.
+type ReferenceExplanation struct {
+	From            string `json:"from"`
+	To              string `json:"to"`
+	TypeOfReference string `json:"type_of_reference"`
+	Rule            string `json:"rule"`
+	Reason          string `json:"reason"`
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/eujoy/erbuilder/internal/config"
 	"github.com/eujoy/erbuilder/internal/domain"
 	test "github.com/eujoy/erbuilder/test/builder"
+
+	"github.com/urfave/cli/v2"
 )
 
 func TestNewOptions(t *testing.T) {
@@ -38,7 +40,34 @@ func TestValidate(t *testing.T) {
 				options.Directory = ""
 				return options
 			}(),
-			expectedError: errors.New("Need to provide at least one of 'directory' or 'file_list'"),
+			expectedError: errors.New("Need to provide at least one of 'directory', 'file_list', 'package', 'sql', 'migrations' or 'sqlite'"),
+		},
+		"Normal setup with sql files instead of directory and list of files": {
+			options: func() domain.Options {
+				options := dataBuilder.GetOptionsForOptionTest(cfg)
+				options.Directory = ""
+				options.SQLFiles = *cli.NewStringSlice("schema.sql")
+				return options
+			}(),
+			expectedError: nil,
+		},
+		"Normal setup with a migrations directory at a migration version": {
+			options: func() domain.Options {
+				options := dataBuilder.GetOptionsForOptionTest(cfg)
+				options.Directory = ""
+				options.Migrations = "./migrations"
+				options.MigrationVersion = "3"
+				return options
+			}(),
+			expectedError: nil,
+		},
+		"Attempt execution by providing a migration version without migrations directory": {
+			options: func() domain.Options {
+				options := dataBuilder.GetOptionsForOptionTest(cfg)
+				options.MigrationVersion = "3"
+				return options
+			}(),
+			expectedError: errors.New("Need to provide 'migrations' to render the schema at a migration version"),
 		},
 		"Attempt execution by providing invalid value for column name case": {
 			options: func() domain.Options {
@@ -62,6 +91,39 @@ func TestValidate(t *testing.T) {
 				cfg.Settings.AllowedTableNameCaseValues,
 			),
 		},
+		"Attempt execution by providing invalid value for dialect": {
+			options: func() domain.Options {
+				options := dataBuilder.GetOptionsForOptionTest(cfg)
+				options.Dialect = "oracle"
+				return options
+			}(),
+			expectedError: fmt.Errorf(
+				"The provided value for dialect is not valid. Allowed values : %v",
+				cfg.Settings.AllowedDialectValues,
+			),
+		},
+		"Attempt execution by providing invalid value for explain format": {
+			options: func() domain.Options {
+				options := dataBuilder.GetOptionsForOptionTest(cfg)
+				options.ExplainFormat = "yaml"
+				return options
+			}(),
+			expectedError: fmt.Errorf(
+				"The provided value for explain format is not valid. Allowed values : %v",
+				cfg.Settings.AllowedExplainFormatValues,
+			),
+		},
+		"Attempt execution by providing invalid value for junction tables": {
+			options: func() domain.Options {
+				options := dataBuilder.GetOptionsForOptionTest(cfg)
+				options.JunctionTables = "drop"
+				return options
+			}(),
+			expectedError: fmt.Errorf(
+				"The provided value for junction tables is not valid. Allowed values : %v",
+				cfg.Settings.AllowedJunctionTableValues,
+			),
+		},
 	}
 
 	for name, tc := range testCases {
@@ -74,6 +136,121 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidateIntrospection(t *testing.T) {
+	cfg := config.New()
+
+	testCases := map[string]struct {
+		options       domain.Options
+		expectedError error
+	}{
+		"Normal setup with valid values": {
+			options:       domain.Options{Config: cfg, DSN: "postgres://localhost:5432/db", Driver: "postgres", Schema: "public"},
+			expectedError: nil,
+		},
+		"Attempt execution without providing the dsn": {
+			options:       domain.Options{Config: cfg, Driver: "postgres"},
+			expectedError: errors.New("Need to provide the 'dsn' of the database to introspect"),
+		},
+		"Attempt execution by providing invalid value for driver": {
+			options: domain.Options{Config: cfg, DSN: "oracle://localhost:1521/db", Driver: "oracle"},
+			expectedError: fmt.Errorf(
+				"The provided value for driver is not valid. Allowed values : %v",
+				cfg.Settings.AllowedDriverValues,
+			),
+		},
+		"Attempt execution by providing invalid value for junction tables": {
+			options: domain.Options{Config: cfg, DSN: "root@tcp(localhost:3306)/db", Driver: "mysql", JunctionTables: "drop"},
+			expectedError: fmt.Errorf(
+				"The provided value for junction tables is not valid. Allowed values : %v",
+				cfg.Settings.AllowedJunctionTableValues,
+			),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualError := tc.options.ValidateIntrospection()
+			if fmt.Sprint(tc.expectedError) != fmt.Sprint(actualError) {
+				t.Errorf("Expected to get '%v' as error but got '%v'.", tc.expectedError, actualError)
+			}
+		})
+	}
+}
+
+func TestValidateDrift(t *testing.T) {
+	cfg := config.New()
+	dataBuilder := test.NewDataBuilder()
+
+	testCases := map[string]struct {
+		options       domain.Options
+		expectedError error
+	}{
+		"Normal setup comparing a directory against sql files": {
+			options: func() domain.Options {
+				options := dataBuilder.GetOptionsForOptionTest(cfg)
+				options.SQLFiles = *cli.NewStringSlice("schema.sql")
+				return options
+			}(),
+			expectedError: nil,
+		},
+		"Normal setup comparing a directory against a database": {
+			options: func() domain.Options {
+				options := dataBuilder.GetOptionsForOptionTest(cfg)
+				options.DSN = "postgres://localhost:5432/db"
+				options.Driver = "postgres"
+				return options
+			}(),
+			expectedError: nil,
+		},
+		"Attempt execution without providing any structs": {
+			options: func() domain.Options {
+				options := dataBuilder.GetOptionsForOptionTest(cfg)
+				options.Directory = ""
+				options.SQLFiles = *cli.NewStringSlice("schema.sql")
+				return options
+			}(),
+			expectedError: errors.New("Need to provide at least one of 'directory', 'file_list' or 'package' to compare the schema against"),
+		},
+		"Attempt execution without providing any schema": {
+			options:       dataBuilder.GetOptionsForOptionTest(cfg),
+			expectedError: errors.New("Need to provide at least one of 'sql', 'migrations', 'sqlite' or 'dsn' to compare the structs against"),
+		},
+		"Attempt execution by providing invalid value for driver": {
+			options: func() domain.Options {
+				options := dataBuilder.GetOptionsForOptionTest(cfg)
+				options.DSN = "oracle://localhost:1521/db"
+				options.Driver = "oracle"
+				return options
+			}(),
+			expectedError: fmt.Errorf(
+				"The provided value for driver is not valid. Allowed values : %v",
+				cfg.Settings.AllowedDriverValues,
+			),
+		},
+		"Attempt execution by providing invalid value for column name case": {
+			options: func() domain.Options {
+				options := dataBuilder.GetOptionsForOptionTest(cfg)
+				options.SQLFiles = *cli.NewStringSlice("schema.sql")
+				options.ColumnNameCase = "invalid_case"
+				return options
+			}(),
+			expectedError: fmt.Errorf(
+				"The provided value for column name case is not valid. Allowed values : %v",
+				cfg.Settings.AllowedColumnNameCaseValues,
+			),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualError := tc.options.ValidateDrift()
+			if fmt.Sprint(tc.expectedError) != fmt.Sprint(actualError) {
+				t.Errorf("Expected to get '%v' as error but got '%v'.", tc.expectedError, actualError)
+			}
+		})
+	}
+}
+
 func TestOptionFlags(t *testing.T) {
 	options := &domain.Options{}
 
@@ -82,6 +259,16 @@ func TestOptionFlags(t *testing.T) {
 		validateFlagIsAsExpected(t, "common_field", actualFlag.Name, "stringSliceFlag", reflect.TypeOf(actualFlag).Name())
 	})
 
+	t.Run("Test GetDescriptions", func(t *testing.T) {
+		actualFlag := options.GetDescriptions()
+		validateFlagIsAsExpected(t, "descriptions", actualFlag.Name, "boolFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
+	t.Run("Test GetDialect", func(t *testing.T) {
+		actualFlag := options.GetDialect()
+		validateFlagIsAsExpected(t, "dialect", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
 	t.Run("Test GetDirectoryFlag", func(t *testing.T) {
 		actualFlag := options.GetDirectoryFlag()
 		validateFlagIsAsExpected(t, "directory", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
@@ -97,11 +284,46 @@ func TestOptionFlags(t *testing.T) {
 		validateFlagIsAsExpected(t, "file_list", actualFlag.Name, "stringSliceFlag", reflect.TypeOf(actualFlag).Name())
 	})
 
+	t.Run("Test GetForeignKeyRules", func(t *testing.T) {
+		actualFlag := options.GetForeignKeyRules()
+		validateFlagIsAsExpected(t, "fk_rule", actualFlag.Name, "stringSliceFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
+	t.Run("Test GetForeignKeyAllowList", func(t *testing.T) {
+		actualFlag := options.GetForeignKeyAllowList()
+		validateFlagIsAsExpected(t, "fk_allow", actualFlag.Name, "stringSliceFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
+	t.Run("Test GetForeignKeyDenyList", func(t *testing.T) {
+		actualFlag := options.GetForeignKeyDenyList()
+		validateFlagIsAsExpected(t, "fk_deny", actualFlag.Name, "stringSliceFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
 	t.Run("Test GetIDField", func(t *testing.T) {
 		actualFlag := options.GetIDField()
 		validateFlagIsAsExpected(t, "id_field", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
 	})
 
+	t.Run("Test GetDriver", func(t *testing.T) {
+		actualFlag := options.GetDriver()
+		validateFlagIsAsExpected(t, "driver", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
+	t.Run("Test GetDSN", func(t *testing.T) {
+		actualFlag := options.GetDSN()
+		validateFlagIsAsExpected(t, "dsn", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
+	t.Run("Test GetExplainFormat", func(t *testing.T) {
+		actualFlag := options.GetExplainFormat()
+		validateFlagIsAsExpected(t, "format", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
+	t.Run("Test GetJunctionTables", func(t *testing.T) {
+		actualFlag := options.GetJunctionTables()
+		validateFlagIsAsExpected(t, "junction_tables", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
 	t.Run("Test GetOutputFilename", func(t *testing.T) {
 		actualFlag := options.GetOutputFilename()
 		validateFlagIsAsExpected(t, "output_filename", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
@@ -112,6 +334,16 @@ func TestOptionFlags(t *testing.T) {
 		validateFlagIsAsExpected(t, "output_path", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
 	})
 
+	t.Run("Test GetPackages", func(t *testing.T) {
+		actualFlag := options.GetPackages()
+		validateFlagIsAsExpected(t, "package", actualFlag.Name, "stringSliceFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
+	t.Run("Test GetSchema", func(t *testing.T) {
+		actualFlag := options.GetSchema()
+		validateFlagIsAsExpected(t, "schema", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
 	t.Run("Test GetTag", func(t *testing.T) {
 		actualFlag := options.GetTag()
 		validateFlagIsAsExpected(t, "tag", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
@@ -132,11 +364,21 @@ func TestOptionFlags(t *testing.T) {
 		validateFlagIsAsExpected(t, "table_name_case", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
 	})
 
+	t.Run("Test GetSyntheticIDField", func(t *testing.T) {
+		actualFlag := options.GetSyntheticIDField()
+		validateFlagIsAsExpected(t, "synthetic_id", actualFlag.Name, "boolFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
 	t.Run("Test GetTableNamePlural", func(t *testing.T) {
 		actualFlag := options.GetTableNamePlural()
 		validateFlagIsAsExpected(t, "table_in_plural", actualFlag.Name, "boolFlag", reflect.TypeOf(actualFlag).Name())
 	})
 
+	t.Run("Test GetTypeMappingFile", func(t *testing.T) {
+		actualFlag := options.GetTypeMappingFile()
+		validateFlagIsAsExpected(t, "type_mapping", actualFlag.Name, "stringFlag", reflect.TypeOf(actualFlag).Name())
+	})
+
 	t.Run("Test GetExtraTablesSurvey", func(t *testing.T) {
 		actualFlag := options.GetExtraTablesSurvey()
 		validateFlagIsAsExpected(t, "extra_tables", actualFlag.Name, "boolFlag", reflect.TypeOf(actualFlag).Name())
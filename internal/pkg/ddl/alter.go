@@ -0,0 +1,284 @@
+package ddl
+
+import (
+	"fmt"
+)
+
+// alterTable parses an `ALTER TABLE` statement, after the `TABLE` keyword, applying its actions to the table in order.
+func (p *parser) alterTable(s *Schema) error {
+	ifExists := p.acceptSequence("IF", "EXISTS")
+	p.accept("ONLY")
+
+	name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+
+	tb, found := s.table(name)
+	if !found {
+		if ifExists {
+			return nil
+		}
+		return fmt.Errorf("table %v does not exist", name)
+	}
+
+	for !p.done() {
+		err := p.alterAction(s, tb)
+		if err != nil {
+			return err
+		}
+
+		p.skipItem()
+		p.accept(",")
+	}
+
+	return nil
+}
+
+// alterAction parses a single action of an `ALTER TABLE` statement (e.g. `ADD COLUMN ...` or `DROP CONSTRAINT ...`),
+// applying it to the table. The actions that do not affect the diagram (e.g. `OWNER TO ...`) are skipped.
+func (p *parser) alterAction(s *Schema, tb *table) error {
+	switch {
+	case p.accept("ADD"):
+		isColumn := p.accept("COLUMN")
+		if !isColumn && p.isTableConstraint() {
+			return p.tableConstraint(tb)
+		}
+
+		ifNotExists := p.acceptSequence("IF", "NOT", "EXISTS")
+		if _, found := tb.column(p.peek().value); found {
+			if ifNotExists {
+				return nil
+			}
+			return fmt.Errorf("column %v already exists on table %v", p.peek().value, tb.name)
+		}
+
+		return p.columnDefinition(tb)
+	case p.accept("DROP"):
+		return p.dropAction(s, tb)
+	case p.accept("RENAME"):
+		return p.renameAction(s, tb)
+	case p.accept("ALTER"):
+		return p.alterColumn(tb)
+	case p.accept("MODIFY"):
+		p.accept("COLUMN")
+		return p.redefineColumn(tb, p.peek().value)
+	case p.accept("CHANGE"):
+		p.accept("COLUMN")
+		oldName, err := p.identifier()
+		if err != nil {
+			return err
+		}
+
+		if !s.renameColumn(tb, oldName, p.peek().value) {
+			return fmt.Errorf("column %v does not exist on table %v", oldName, tb.name)
+		}
+		return p.redefineColumn(tb, p.peek().value)
+	default:
+		p.next()
+	}
+
+	return nil
+}
+
+// dropAction parses a `DROP` action of an `ALTER TABLE` statement, dropping either a column or a constraint.
+func (p *parser) dropAction(s *Schema, tb *table) error {
+	switch {
+	case p.acceptSequence("PRIMARY", "KEY"):
+		tb.primaryKey = nil
+		tb.primaryKeyName = ""
+	case p.accept("CONSTRAINT"), p.acceptSequence("FOREIGN", "KEY"):
+		ifExists := p.acceptSequence("IF", "EXISTS")
+		name, err := p.identifier()
+		if err != nil {
+			return err
+		}
+
+		if !tb.dropConstraint(name) && !ifExists {
+			return fmt.Errorf("constraint %v does not exist on table %v", name, tb.name)
+		}
+	case p.accept("INDEX", "KEY"):
+		name, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		tb.dropUniqueKey(name)
+	default:
+		p.accept("COLUMN")
+		ifExists := p.acceptSequence("IF", "EXISTS")
+		name, err := p.identifier()
+		if err != nil {
+			return err
+		}
+
+		if !s.dropColumn(tb, name) && !ifExists {
+			return fmt.Errorf("column %v does not exist on table %v", name, tb.name)
+		}
+	}
+
+	return nil
+}
+
+// renameAction parses a `RENAME` action of an `ALTER TABLE` statement, renaming either the table, a column or a
+// constraint.
+func (p *parser) renameAction(s *Schema, tb *table) error {
+	if p.accept("TO", "AS") {
+		name, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		return s.renameTable(tb, name)
+	}
+
+	isConstraint := p.accept("CONSTRAINT", "INDEX", "KEY")
+	isColumn := p.accept("COLUMN")
+	oldName, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+
+	// mysql renames the table without the `TO` keyword as well (e.g. `ALTER TABLE a RENAME b`).
+	if !p.accept("TO") {
+		if isConstraint || isColumn {
+			return fmt.Errorf("expected TO for the renaming of %v", oldName)
+		}
+		return s.renameTable(tb, oldName)
+	}
+
+	newName, err := p.identifier()
+	if err != nil {
+		return err
+	}
+
+	if isConstraint {
+		tb.renameConstraint(oldName, newName)
+		return nil
+	}
+
+	if !s.renameColumn(tb, oldName, newName) {
+		return fmt.Errorf("column %v does not exist on table %v", oldName, tb.name)
+	}
+
+	return nil
+}
+
+// alterColumn parses an `ALTER COLUMN` action of an `ALTER TABLE` statement, changing the data type, the nullability or
+// the default value of the column.
+func (p *parser) alterColumn(tb *table) error {
+	p.accept("COLUMN")
+	name, err := p.identifier()
+	if err != nil {
+		return err
+	}
+
+	cl, found := tb.column(name)
+	if !found {
+		return fmt.Errorf("column %v does not exist on table %v", name, tb.name)
+	}
+
+	switch {
+	case p.acceptSequence("SET", "NOT", "NULL"):
+		cl.isNotNull = true
+	case p.acceptSequence("DROP", "NOT", "NULL"):
+		cl.isNotNull = false
+	case p.acceptSequence("SET", "DATA", "TYPE"), p.accept("TYPE"):
+		cl.dataType = p.dataType()
+	case p.acceptSequence("SET", "DEFAULT"):
+		cl.defaultValue = p.expression()
+	case p.acceptSequence("DROP", "DEFAULT"):
+		cl.defaultValue = ""
+	}
+
+	return nil
+}
+
+// redefineColumn parses the new definition of an existing column (e.g. `MODIFY COLUMN ...` of mysql), replacing the
+// column in place.
+func (p *parser) redefineColumn(tb *table, name string) error {
+	idx := tb.columnIndex(name)
+	if idx < 0 {
+		return fmt.Errorf("column %v does not exist on table %v", name, tb.name)
+	}
+
+	err := p.columnDefinition(tb)
+	if err != nil {
+		return err
+	}
+
+	tb.columns[idx] = tb.columns[len(tb.columns)-1]
+	tb.columns = tb.columns[:len(tb.columns)-1]
+	return nil
+}
+
+// dropTable parses a `DROP TABLE` statement, after the `TABLE` keyword, dropping the tables alongside with the foreign
+// keys referring to them.
+func (p *parser) dropTable(s *Schema) error {
+	ifExists := p.acceptSequence("IF", "EXISTS")
+	for {
+		name, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+
+		if !s.dropTable(name) && !ifExists {
+			return fmt.Errorf("table %v does not exist", name)
+		}
+
+		if !p.accept(",") {
+			return nil
+		}
+	}
+}
+
+// dropIndex parses a `DROP INDEX` statement, after the `INDEX` keyword, dropping the respective unique keys.
+func (p *parser) dropIndex(s *Schema) error {
+	p.accept("CONCURRENTLY")
+	p.acceptSequence("IF", "EXISTS")
+	for {
+		name, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+
+		for _, tb := range s.tables {
+			tb.dropUniqueKey(name)
+		}
+
+		if !p.accept(",") {
+			return nil
+		}
+	}
+}
+
+// renameTables parses a `RENAME TABLE` statement of mysql, after the `TABLE` keyword, renaming the tables in order.
+func (p *parser) renameTables(s *Schema) error {
+	for {
+		oldName, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+
+		if !p.accept("TO") {
+			return fmt.Errorf("expected TO for the renaming of %v", oldName)
+		}
+
+		newName, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+
+		tb, found := s.table(oldName)
+		if !found {
+			return fmt.Errorf("table %v does not exist", oldName)
+		}
+
+		err = s.renameTable(tb, newName)
+		if err != nil {
+			return err
+		}
+
+		if !p.accept(",") {
+			return nil
+		}
+	}
+}
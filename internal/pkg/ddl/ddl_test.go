@@ -0,0 +1,391 @@
+package ddl_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+	"github.com/eujoy/erbuilder/internal/pkg/ddl"
+)
+
+func TestApply(t *testing.T) {
+	testCases := map[string]struct {
+		content        string
+		expectedTables []domain.Table
+		expectError    bool
+	}{
+		"Parse a table with column types, nullability, defaults and an inline primary key": {
+			content: `
+				CREATE TABLE IF NOT EXISTS public.users (
+					id BIGSERIAL PRIMARY KEY,
+					email VARCHAR(255) NOT NULL UNIQUE,
+					balance NUMERIC(10, 2) DEFAULT 0 NOT NULL,
+					created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+					tags TEXT[]
+				);`,
+			expectedTables: []domain.Table{
+				{
+					Name: "users",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "bigserial", IsPrimaryKey: true},
+						{Name: "email", Type: "varchar(255)", IsUnique: true},
+						{Name: "balance", Type: "numeric(10,2)", Default: "0"},
+						{Name: "created_at", Type: "timestamp with time zone", IsNullable: true, Default: "now()"},
+						{Name: "tags", Type: "text[]", IsNullable: true},
+					},
+				},
+			},
+		},
+		"Parse inline and table level foreign keys, referring to the primary key when no columns are declared": {
+			content: `
+				CREATE TABLE "user" (id INT PRIMARY KEY);
+				CREATE TABLE post (
+					id INT NOT NULL,
+					author_id INT NOT NULL REFERENCES "user" ON DELETE CASCADE,
+					editor_id INT,
+					CONSTRAINT post_pkey PRIMARY KEY (id),
+					CONSTRAINT post_editor_fk FOREIGN KEY (editor_id) REFERENCES "user" (id) ON UPDATE SET NULL
+				);`,
+			expectedTables: []domain.Table{
+				{
+					Name:       "user",
+					ColumnList: []domain.Column{{Name: "id", Type: "int", IsPrimaryKey: true}},
+				},
+				{
+					Name: "post",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "int", IsPrimaryKey: true},
+						{Name: "author_id", Type: "int", IsForeignKey: true, ReferencedTable: "user", ReferencedColumn: "id"},
+						{Name: "editor_id", Type: "int", IsForeignKey: true, IsNullable: true, ReferencedTable: "user", ReferencedColumn: "id"},
+					},
+				},
+			},
+		},
+		"Parse composite primary and foreign keys": {
+			content: `
+				CREATE TABLE tenant_user (tenant_id INT, user_id INT, PRIMARY KEY (tenant_id, user_id));
+				CREATE TABLE tenant_order (
+					id INT PRIMARY KEY,
+					tenant_id INT NOT NULL,
+					user_id INT NOT NULL,
+					CONSTRAINT order_user_fk FOREIGN KEY (tenant_id, user_id) REFERENCES tenant_user (tenant_id, user_id)
+				);`,
+			expectedTables: []domain.Table{
+				{
+					Name: "tenant_user",
+					ColumnList: []domain.Column{
+						{Name: "tenant_id", Type: "int", IsPrimaryKey: true},
+						{Name: "user_id", Type: "int", IsPrimaryKey: true},
+					},
+				},
+				{
+					Name: "tenant_order",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "int", IsPrimaryKey: true},
+						{Name: "tenant_id", Type: "int", IsForeignKey: true, ReferencedTable: "tenant_user", ReferencedColumn: "tenant_id", ForeignKeyName: "order_user_fk"},
+						{Name: "user_id", Type: "int", IsForeignKey: true, ReferencedTable: "tenant_user", ReferencedColumn: "user_id", ForeignKeyName: "order_user_fk"},
+					},
+				},
+			},
+		},
+		"Parse the comments and the unique indexes of the tables and columns": {
+			content: `
+				/* the accounts of the users */
+				CREATE TABLE account (
+					id INT PRIMARY KEY,
+					handle VARCHAR(32) NOT NULL COMMENT 'The public name',
+					slug VARCHAR(32) NOT NULL
+				) ENGINE=InnoDB COMMENT='The accounts';
+				CREATE UNIQUE INDEX account_slug_idx ON account USING btree (slug);
+				COMMENT ON COLUMN public.account.slug IS 'The url of the account';
+				-- the functions are skipped as a whole.
+				CREATE FUNCTION touch() RETURNS trigger AS $$ BEGIN NEW.updated_at = now(); RETURN NEW; END; $$ LANGUAGE plpgsql;`,
+			expectedTables: []domain.Table{
+				{
+					Name:        "account",
+					Description: "The accounts",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "int", IsPrimaryKey: true},
+						{Name: "handle", Type: "varchar(32)", Description: "The public name"},
+						{Name: "slug", Type: "varchar(32)", IsUnique: true, Description: "The url of the account"},
+					},
+				},
+			},
+		},
+		"Parse the columns named after the keywords of the table constraints": {
+			content: `
+				CREATE TABLE settings (key text NOT NULL PRIMARY KEY, value text);
+				CREATE TABLE slot (index int, key varchar(32), KEY slot_key_idx (key), INDEX (index));`,
+			expectedTables: []domain.Table{
+				{
+					Name: "settings",
+					ColumnList: []domain.Column{
+						{Name: "key", Type: "text", IsPrimaryKey: true},
+						{Name: "value", Type: "text", IsNullable: true},
+					},
+				},
+				{
+					Name: "slot",
+					ColumnList: []domain.Column{
+						{Name: "index", Type: "int", IsNullable: true},
+						{Name: "key", Type: "varchar(32)", IsNullable: true},
+					},
+				},
+			},
+		},
+		"Omit the foreign keys referring to tables missing from the schema": {
+			content: `CREATE TABLE post (id INT PRIMARY KEY, author_id INT REFERENCES author (id));`,
+			expectedTables: []domain.Table{
+				{
+					Name: "post",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "int", IsPrimaryKey: true},
+						{Name: "author_id", Type: "int", IsNullable: true},
+					},
+				},
+			},
+		},
+		"Apply the alterations of the tables, renaming them and dropping their constraints by the default names": {
+			content: `
+				CREATE TABLE author (id INT PRIMARY KEY, email TEXT UNIQUE);
+				CREATE TABLE book (id INT PRIMARY KEY, author_id INT REFERENCES author, isbn TEXT, draft TEXT);
+				ALTER TABLE author RENAME TO writer;
+				ALTER TABLE writer DROP CONSTRAINT writer_email_key;
+				ALTER TABLE book RENAME COLUMN author_id TO writer_id;
+				ALTER TABLE book DROP COLUMN IF EXISTS draft, ALTER COLUMN isbn TYPE VARCHAR(13) USING isbn::varchar(13);
+				ALTER TABLE ONLY book ALTER COLUMN isbn SET NOT NULL, OWNER TO postgres;`,
+			expectedTables: []domain.Table{
+				{
+					Name: "writer",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "int", IsPrimaryKey: true},
+						{Name: "email", Type: "text", IsNullable: true},
+					},
+				},
+				{
+					Name: "book",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "int", IsPrimaryKey: true},
+						{Name: "writer_id", Type: "int", IsForeignKey: true, IsNullable: true, ReferencedTable: "writer", ReferencedColumn: "id"},
+						{Name: "isbn", Type: "varchar(13)"},
+					},
+				},
+			},
+		},
+		"Apply the alterations of mysql, dropping the foreign keys and the tables referred to": {
+			content: "CREATE TABLE `tag` (`id` INT PRIMARY KEY);\n" +
+				"CREATE TABLE `label` (`id` INT PRIMARY KEY);\n" +
+				"CREATE TABLE `post` (`id` INT PRIMARY KEY, `tag_id` INT, `label_id` INT, `title` TEXT,\n" +
+				"  CONSTRAINT `post_tag_fk` FOREIGN KEY (`tag_id`) REFERENCES `tag` (`id`),\n" +
+				"  FOREIGN KEY (`label_id`) REFERENCES `label` (`id`));\n" +
+				"ALTER TABLE `post` DROP FOREIGN KEY `post_tag_fk`, MODIFY `title` VARCHAR(100) NOT NULL AFTER `id`;\n" +
+				"ALTER TABLE `post` CHANGE COLUMN `tag_id` `topic_id` BIGINT;\n" +
+				"DROP TABLE IF EXISTS `label`, `unknown`;\n" +
+				"RENAME TABLE `post` TO `article`;",
+			expectedTables: []domain.Table{
+				{
+					Name:       "tag",
+					ColumnList: []domain.Column{{Name: "id", Type: "int", IsPrimaryKey: true}},
+				},
+				{
+					Name: "article",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "int", IsPrimaryKey: true},
+						{Name: "topic_id", Type: "bigint", IsNullable: true},
+						{Name: "label_id", Type: "int", IsNullable: true},
+						{Name: "title", Type: "varchar(100)"},
+					},
+				},
+			},
+		},
+		"Attempt to alter a table that does not exist": {
+			content:     `ALTER TABLE post ADD COLUMN title TEXT;`,
+			expectError: true,
+		},
+		"Attempt to drop a column that does not exist": {
+			content:     `CREATE TABLE post (id INT); ALTER TABLE post DROP COLUMN title;`,
+			expectError: true,
+		},
+		"Attempt to drop a table that does not exist": {
+			content:     `DROP TABLE post;`,
+			expectError: true,
+		},
+		"Attempt to create a table that already exists": {
+			content:     `CREATE TABLE post (id INT); CREATE TABLE post (id INT);`,
+			expectError: true,
+		},
+		"Attempt to parse an unterminated table definition": {
+			content:     `CREATE TABLE post (id INT`,
+			expectError: true,
+		},
+		"Attempt to parse an unterminated string": {
+			content:     `COMMENT ON TABLE post IS 'the posts`,
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schema := ddl.NewSchema()
+			err := schema.Apply(tc.content)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected to get an error but got nil.")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected to get no error but got '%v'.", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedTables, schema.Tables()) {
+				t.Errorf("Expected to get tables '%+v' but got '%+v'.", tc.expectedTables, schema.Tables())
+			}
+		})
+	}
+}
+
+func TestApplyMigrations(t *testing.T) {
+	testCases := map[string]struct {
+		directory      string
+		version        string
+		expectedTables []domain.Table
+		expectError    bool
+	}{
+		"Apply all the up migrations of golang-migrate": {
+			directory: "./../../../testdata/migrations/golang-migrate",
+			expectedTables: []domain.Table{
+				{
+					Name: "users",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "serial", IsPrimaryKey: true},
+						{Name: "full_name", Type: "text"},
+						{Name: "email", Type: "varchar(255)", IsUnique: true},
+						{Name: "created_at", Type: "timestamp", Default: "now()"},
+					},
+				},
+				{
+					Name: "orders",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "serial", IsPrimaryKey: true},
+						{Name: "user_id", Type: "integer", IsForeignKey: true, ReferencedTable: "users", ReferencedColumn: "id"},
+						{Name: "amount_cents", Type: "bigint", Default: "0"},
+					},
+				},
+				{
+					Name: "payments",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "serial", IsPrimaryKey: true},
+						{Name: "order_id", Type: "integer", IsForeignKey: true, ReferencedTable: "orders", ReferencedColumn: "id"},
+						{Name: "paid_at", Type: "timestamp", IsNullable: true},
+					},
+				},
+			},
+		},
+		"Apply the migrations of golang-migrate up to an intermediate version": {
+			directory: "./../../../testdata/migrations/golang-migrate",
+			version:   "2",
+			expectedTables: []domain.Table{
+				{
+					Name: "users",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "serial", IsPrimaryKey: true},
+						{Name: "name", Type: "text"},
+						{Name: "email", Type: "varchar(255)", IsNullable: true},
+					},
+				},
+				{
+					Name: "orders",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "serial", IsPrimaryKey: true},
+						{Name: "user_id", Type: "integer", IsForeignKey: true, ReferencedTable: "users", ReferencedColumn: "id"},
+						{Name: "total", Type: "numeric(10,2)", IsNullable: true},
+					},
+				},
+				{
+					Name: "legacy_audit",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "serial", IsPrimaryKey: true},
+						{Name: "user_id", Type: "integer", IsForeignKey: true, IsNullable: true, ReferencedTable: "users", ReferencedColumn: "id"},
+						{Name: "payload", Type: "text", IsNullable: true},
+					},
+				},
+			},
+		},
+		"Apply the up sections of the goose migrations": {
+			directory: "./../../../testdata/migrations/goose",
+			expectedTables: []domain.Table{
+				{
+					Name: "accounts",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "bigint", IsPrimaryKey: true},
+						{Name: "username", Type: "text"},
+					},
+				},
+				{
+					Name: "sessions",
+					ColumnList: []domain.Column{
+						{Name: "token", Type: "text", IsPrimaryKey: true},
+						{Name: "account_id", Type: "bigint", IsForeignKey: true, ReferencedTable: "accounts", ReferencedColumn: "id"},
+					},
+				},
+			},
+		},
+		"Apply the plain numbered migrations, skipping the files without a version": {
+			directory: "./../../../testdata/migrations/plain",
+			expectedTables: []domain.Table{
+				{
+					Name: "authors",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "integer", IsPrimaryKey: true},
+						{Name: "name", Type: "text"},
+					},
+				},
+				{
+					Name: "books",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "integer", IsPrimaryKey: true},
+						{Name: "title", Type: "text"},
+						{Name: "author_id", Type: "integer", IsForeignKey: true, IsNullable: true, ReferencedTable: "authors", ReferencedColumn: "id"},
+					},
+				},
+			},
+		},
+		"Attempt to apply the migrations up to a version that does not exist": {
+			directory:   "./../../../testdata/migrations/golang-migrate",
+			version:     "5",
+			expectError: true,
+		},
+		"Attempt to apply the migrations up to an invalid version": {
+			directory:   "./../../../testdata/migrations/golang-migrate",
+			version:     "latest",
+			expectError: true,
+		},
+		"Attempt to apply the migrations of a directory that does not exist": {
+			directory:   "./../../../testdata/migrations/unknown",
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schema := ddl.NewSchema()
+			err := schema.ApplyMigrations(tc.directory, tc.version)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected to get an error but got nil.")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected to get no error but got '%v'.", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedTables, schema.Tables()) {
+				t.Errorf("Expected to get tables '%+v' but got '%+v'.", tc.expectedTables, schema.Tables())
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind describes the kind of a token of a sql statement.
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenQuoted
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+// token describes a token of a sql statement alongside with the line it starts at.
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+}
+
+// is checks whether the token is the provided keyword or symbol, case insensitively. Quoted identifiers and strings
+// are never keywords.
+func (t token) is(values ...string) bool {
+	if t.kind != tokenWord && t.kind != tokenSymbol {
+		return false
+	}
+
+	for _, value := range values {
+		if strings.EqualFold(t.value, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tokenize splits sql content into statements of tokens, separated by semicolons and skipping the comments.
+func tokenize(content string) ([][]token, error) {
+	var statements [][]token
+	var current []token
+
+	runes := []rune(content)
+	line := 1
+	for idx := 0; idx < len(runes); {
+		r := runes[idx]
+		switch {
+		case r == '\n':
+			line++
+			idx++
+		case unicode.IsSpace(r):
+			idx++
+		case r == '-' && idx+1 < len(runes) && runes[idx+1] == '-':
+			for idx < len(runes) && runes[idx] != '\n' {
+				idx++
+			}
+		case r == '/' && idx+1 < len(runes) && runes[idx+1] == '*':
+			rest := string(runes[idx+2:])
+			end := strings.Index(rest, "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %v: unterminated comment", line)
+			}
+			line += strings.Count(rest[:end], "\n")
+			idx += len([]rune(rest[:end])) + 4
+		case r == ';':
+			if len(current) > 0 {
+				statements = append(statements, current)
+				current = nil
+			}
+			idx++
+		case r == '\'':
+			value, length, err := readQuoted(runes[idx:], '\'')
+			if err != nil {
+				return nil, fmt.Errorf("line %v: %v", line, err)
+			}
+			current = append(current, token{kind: tokenString, value: value, line: line})
+			line += strings.Count(string(runes[idx:idx+length]), "\n")
+			idx += length
+		case r == '"' || r == '`':
+			value, length, err := readQuoted(runes[idx:], r)
+			if err != nil {
+				return nil, fmt.Errorf("line %v: %v", line, err)
+			}
+			current = append(current, token{kind: tokenQuoted, value: value, line: line})
+			idx += length
+		case r == '$' && isDollarQuote(runes[idx:]):
+			// dollar quoted strings (e.g. the bodies of postgres functions) are skipped as a whole.
+			rest := string(runes[idx:])
+			tag := rest[:strings.IndexRune(rest[1:], '$')+2]
+			end := strings.Index(rest[len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("line %v: unterminated dollar quoted string", line)
+			}
+			body := rest[len(tag) : len(tag)+end]
+			current = append(current, token{kind: tokenString, value: body, line: line})
+			line += strings.Count(body, "\n")
+			idx += len([]rune(tag))*2 + len([]rune(body))
+		case unicode.IsDigit(r):
+			start := idx
+			for idx < len(runes) && (unicode.IsDigit(runes[idx]) || runes[idx] == '.') {
+				idx++
+			}
+			current = append(current, token{kind: tokenNumber, value: string(runes[start:idx]), line: line})
+		case unicode.IsLetter(r) || r == '_':
+			start := idx
+			for idx < len(runes) && (unicode.IsLetter(runes[idx]) || unicode.IsDigit(runes[idx]) || runes[idx] == '_' || runes[idx] == '$') {
+				idx++
+			}
+			current = append(current, token{kind: tokenWord, value: string(runes[start:idx]), line: line})
+		default:
+			current = append(current, token{kind: tokenSymbol, value: string(r), line: line})
+			idx++
+		}
+	}
+
+	if len(current) > 0 {
+		statements = append(statements, current)
+	}
+
+	return statements, nil
+}
+
+// readQuoted reads a value enclosed in the provided quote, where a doubled quote escapes the quote itself, and returns
+// the value alongside with the number of runes read.
+func readQuoted(runes []rune, quote rune) (string, int, error) {
+	var value strings.Builder
+	for idx := 1; idx < len(runes); idx++ {
+		if runes[idx] != quote {
+			value.WriteRune(runes[idx])
+			continue
+		}
+
+		if idx+1 < len(runes) && runes[idx+1] == quote {
+			value.WriteRune(quote)
+			idx++
+			continue
+		}
+
+		return value.String(), idx + 1, nil
+	}
+
+	return "", 0, fmt.Errorf("unterminated quoted value")
+}
+
+// isDollarQuote checks whether the runes start with a dollar quote tag (e.g. `$$` or `$body$`).
+func isDollarQuote(runes []rune) bool {
+	for idx := 1; idx < len(runes); idx++ {
+		if runes[idx] == '$' {
+			return true
+		}
+
+		if !unicode.IsLetter(runes[idx]) && runes[idx] != '_' {
+			return false
+		}
+	}
+
+	return false
+}
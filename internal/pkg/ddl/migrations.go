@@ -0,0 +1,130 @@
+package ddl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// migrationFilenamePattern matches the filename of a migration, starting with its version (e.g. `001_create_users.up.sql`
+// of golang-migrate or `20230102150405_add_email.sql` of goose).
+var migrationFilenamePattern = regexp.MustCompile(`^(\d+)\D?.*\.sql$`)
+
+// gooseUpPattern and gooseDownPattern match the annotations of the sections of a goose migration.
+var (
+	gooseUpPattern   = regexp.MustCompile(`(?mi)^\s*--\s*\+goose\s+up\b.*$`)
+	gooseDownPattern = regexp.MustCompile(`(?mi)^\s*--\s*\+goose\s+down\b.*$`)
+)
+
+// Migration describes a migration of a directory, identified by the version that its filename starts with.
+type Migration struct {
+	Version  uint64
+	Filename string
+}
+
+// LoadMigrations returns the migrations of a directory ordered by their version. The down migrations of golang-migrate
+// (e.g. `001_create_users.down.sql`) and the files not starting with a version are skipped.
+func LoadMigrations(directory string) ([]Migration, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	versions := map[uint64]string{}
+	for _, entry := range entries {
+		matches := migrationFilenamePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || matches == nil || strings.HasSuffix(entry.Name(), ".down.sql") {
+			continue
+		}
+
+		version, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		if existing, found := versions[version]; found {
+			return nil, fmt.Errorf("migrations %v and %v have the same version %v", existing, entry.Name(), version)
+		}
+		versions[version] = entry.Name()
+
+		migrations = append(migrations, Migration{Version: version, Filename: filepath.Join(directory, entry.Name())})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// ApplyMigrations applies the migrations of a directory in order, up to the provided version if any (e.g. `3` or
+// `20230102150405`), so that the schema describes the state of the database after that migration.
+func (s *Schema) ApplyMigrations(directory, version string) error {
+	migrations, err := LoadMigrations(directory)
+	if err != nil {
+		return err
+	}
+
+	target := uint64(0)
+	if version != "" {
+		target, err = strconv.ParseUint(version, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid migration version %v", version)
+		}
+
+		if !containsVersion(migrations, target) {
+			return fmt.Errorf("migration version %v not found in %v", version, directory)
+		}
+	}
+
+	for _, migration := range migrations {
+		if version != "" && migration.Version > target {
+			break
+		}
+
+		content, err := os.ReadFile(migration.Filename)
+		if err != nil {
+			return err
+		}
+
+		err = s.Apply(getUpMigration(string(content)))
+		if err != nil {
+			return fmt.Errorf("%v: %v", migration.Filename, err)
+		}
+	}
+
+	return nil
+}
+
+// getUpMigration returns the statements migrating the database up. For goose migrations, it is the section following
+// the `-- +goose Up` annotation, otherwise the whole content of the migration.
+func getUpMigration(content string) string {
+	up := gooseUpPattern.FindStringIndex(content)
+	if up == nil {
+		return content
+	}
+
+	// the section is kept on the same lines, so that the errors refer to the lines of the migration.
+	section := strings.Repeat("\n", strings.Count(content[:up[1]], "\n")) + content[up[1]:]
+	if down := gooseDownPattern.FindStringIndex(section); down != nil {
+		section = section[:down[0]]
+	}
+
+	return section
+}
+
+// containsVersion checks whether a list of migrations contains the provided version.
+func containsVersion(migrations []Migration, version uint64) bool {
+	for _, migration := range migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+
+	return false
+}
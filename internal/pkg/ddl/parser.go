@@ -0,0 +1,586 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// columnConstraintKeywords describes the keywords that end the data type of a column definition.
+var columnConstraintKeywords = []string{
+	"AS", "AUTO_INCREMENT", "AUTOINCREMENT", "CHARSET", "CHECK", "COLLATE", "COMMENT", "CONSTRAINT", "DEFAULT",
+	"GENERATED", "IDENTITY", "NOT", "NULL", "ON", "PRIMARY", "REFERENCES", "UNIQUE", "USING",
+}
+
+// tableConstraintKeywords describes the keywords that start a table constraint instead of a column definition.
+var tableConstraintKeywords = []string{
+	"CHECK", "CONSTRAINT", "EXCLUDE", "FOREIGN", "FULLTEXT", "INDEX", "KEY", "LIKE", "PRIMARY", "SPATIAL", "UNIQUE",
+}
+
+// columnNameKeywords describes the keywords of the table constraints that are valid column names as well (e.g. `key` or
+// `index` in postgres and sqlite).
+var columnNameKeywords = []string{"INDEX", "KEY", "LIKE"}
+
+// parser describes the parsing state of the tokens of a single statement.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// apply parses the statement and applies it to the schema.
+func (p *parser) apply(s *Schema) error {
+	switch {
+	case p.accept("CREATE"):
+		p.accept("OR")
+		p.accept("REPLACE")
+		p.accept("GLOBAL", "LOCAL")
+		p.accept("TEMPORARY", "TEMP")
+		p.accept("UNLOGGED")
+
+		if p.accept("TABLE") {
+			return p.createTable(s)
+		}
+
+		isUnique := p.accept("UNIQUE")
+		if p.accept("INDEX") {
+			return p.createIndex(s, isUnique)
+		}
+	case p.acceptSequence("COMMENT", "ON"):
+		return p.commentOn(s)
+	case p.acceptSequence("ALTER", "TABLE"):
+		return p.alterTable(s)
+	case p.acceptSequence("DROP", "TABLE"):
+		return p.dropTable(s)
+	case p.acceptSequence("DROP", "INDEX"):
+		return p.dropIndex(s)
+	case p.acceptSequence("RENAME", "TABLE"):
+		return p.renameTables(s)
+	}
+
+	return nil
+}
+
+// createTable parses a `CREATE TABLE` statement, after the `TABLE` keyword, adding the table to the schema.
+func (p *parser) createTable(s *Schema) error {
+	ifNotExists := p.acceptSequence("IF", "NOT", "EXISTS")
+	name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+
+	if _, found := s.table(name); found {
+		if ifNotExists {
+			return nil
+		}
+		return fmt.Errorf("table %v already exists", name)
+	}
+
+	// tables created from a query or another table (e.g. `CREATE TABLE t AS SELECT ...`) are not supported.
+	if !p.accept("(") {
+		return nil
+	}
+
+	tb := &table{name: name}
+	for !p.accept(")") {
+		if p.done() {
+			return fmt.Errorf("unterminated definition of table %v", name)
+		}
+
+		if p.isTableConstraint() {
+			err = p.tableConstraint(tb)
+		} else {
+			err = p.columnDefinition(tb)
+		}
+		if err != nil {
+			return err
+		}
+
+		p.accept(",")
+	}
+
+	// the table options of mysql may describe the table (e.g. `COMMENT='...'`).
+	for !p.done() {
+		if p.accept("COMMENT") {
+			p.accept("=")
+			tb.description = p.next().value
+			continue
+		}
+		p.next()
+	}
+
+	s.tables = append(s.tables, tb)
+	return nil
+}
+
+// columnDefinition parses the definition of a column alongside with its constraints, adding it to the table.
+func (p *parser) columnDefinition(tb *table) error {
+	name, err := p.identifier()
+	if err != nil {
+		return err
+	}
+
+	cl := &column{name: name, dataType: p.dataType()}
+	tb.columns = append(tb.columns, cl)
+
+	constraintName := ""
+	for !p.done() && !p.peek().is(",", ")") {
+		switch {
+		case p.accept("CONSTRAINT"):
+			constraintName, err = p.identifier()
+			if err != nil {
+				return err
+			}
+			continue
+		case p.acceptSequence("NOT", "NULL"):
+			cl.isNotNull = true
+		case p.accept("NULL"):
+			cl.isNotNull = false
+		case p.acceptSequence("PRIMARY", "KEY"):
+			tb.primaryKey = []string{name}
+			tb.primaryKeyName = constraintName
+			p.accept("ASC", "DESC")
+		case p.accept("UNIQUE"):
+			p.accept("KEY")
+			tb.uniqueKeys = append(tb.uniqueKeys, constraint{name: constraintName, columns: []string{name}})
+		case p.accept("REFERENCES"):
+			fk, err := p.references(constraint{name: constraintName, columns: []string{name}})
+			if err != nil {
+				return err
+			}
+			tb.foreignKeys = append(tb.foreignKeys, fk)
+		case p.accept("DEFAULT"):
+			cl.defaultValue = p.expression()
+		case p.accept("COMMENT"):
+			cl.description = p.next().value
+		case p.accept("COLLATE", "CHARSET"), p.acceptSequence("CHARACTER", "SET"):
+			p.next()
+		case p.acceptSequence("ON", "UPDATE"):
+			p.expression()
+		case p.accept("GENERATED"):
+			for !p.done() && !p.accept("AS") {
+				p.next()
+			}
+			p.accept("IDENTITY")
+			p.skipGroup()
+		default:
+			// any other constraint (e.g. `CHECK (...)` or `AUTO_INCREMENT`) is skipped along with its arguments.
+			p.next()
+			p.skipGroup()
+		}
+		constraintName = ""
+	}
+
+	return nil
+}
+
+// tableConstraint parses a constraint of a table (e.g. `PRIMARY KEY (a, b)` or `FOREIGN KEY (a) REFERENCES t (b)`).
+// The indexes and checks are skipped.
+func (p *parser) tableConstraint(tb *table) error {
+	constraintName := ""
+	if p.accept("CONSTRAINT") {
+		name, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		constraintName = name
+	}
+
+	switch {
+	case p.acceptSequence("PRIMARY", "KEY"):
+		if !p.peek().is("(") {
+			break
+		}
+		columns, err := p.columnList()
+		if err != nil {
+			return err
+		}
+		tb.primaryKey = columns
+		tb.primaryKeyName = constraintName
+	case p.accept("UNIQUE"):
+		p.accept("KEY", "INDEX")
+		if !p.peek().is("(") {
+			constraintName = p.next().value
+		}
+		// the constraints based on existing indexes (e.g. `UNIQUE USING INDEX ...`) are skipped.
+		if !p.peek().is("(") {
+			break
+		}
+		columns, err := p.columnList()
+		if err != nil {
+			return err
+		}
+		tb.uniqueKeys = append(tb.uniqueKeys, constraint{name: constraintName, columns: columns})
+	case p.acceptSequence("FOREIGN", "KEY"):
+		if !p.peek().is("(") {
+			p.next()
+		}
+		columns, err := p.columnList()
+		if err != nil {
+			return err
+		}
+		if !p.accept("REFERENCES") {
+			return fmt.Errorf("expected REFERENCES for the foreign key of table %v", tb.name)
+		}
+		fk, err := p.references(constraint{name: constraintName, columns: columns})
+		if err != nil {
+			return err
+		}
+		tb.foreignKeys = append(tb.foreignKeys, fk)
+	}
+
+	p.skipItem()
+	return nil
+}
+
+// isTableConstraint checks whether the next tokens start a table constraint instead of a column definition. The
+// keywords that are valid column names as well start a constraint only when followed by its columns, either directly
+// or after its name (e.g. `KEY (a)` or `INDEX idx_a (a)`), or by the options of the copied table (e.g.
+// `LIKE t INCLUDING ALL`), so that the columns named after them (e.g. `key text` or `index int`) are kept.
+func (p *parser) isTableConstraint() bool {
+	if !p.peek().is(tableConstraintKeywords...) {
+		return false
+	}
+
+	if !p.peek().is(columnNameKeywords...) || p.peekAt(1).is("(") {
+		return true
+	}
+
+	// the lengths and the values of the data types (e.g. `varchar(32)` or `enum('a')`) are not column names.
+	if p.peekAt(2).is("(") {
+		return p.peekAt(3).kind == tokenWord || p.peekAt(3).kind == tokenQuoted
+	}
+
+	return p.peekAt(2).is("USING", "INCLUDING", "EXCLUDING")
+}
+
+// references parses the table and the columns that a foreign key refers to, after the `REFERENCES` keyword, skipping
+// its referential actions (e.g. `ON DELETE CASCADE`).
+func (p *parser) references(c constraint) (foreignKey, error) {
+	referencedTable, err := p.qualifiedName()
+	if err != nil {
+		return foreignKey{}, err
+	}
+
+	fk := foreignKey{constraint: c, referencedTable: referencedTable}
+	if p.peek().is("(") {
+		fk.referencedColumns, err = p.columnList()
+		if err != nil {
+			return foreignKey{}, err
+		}
+	}
+
+	for {
+		switch {
+		case p.acceptSequence("ON", "DELETE"), p.acceptSequence("ON", "UPDATE"):
+			if !p.acceptSequence("NO", "ACTION") && !p.acceptSequence("SET", "NULL") && !p.acceptSequence("SET", "DEFAULT") {
+				p.next()
+			}
+		case p.accept("MATCH"), p.accept("INITIALLY"):
+			p.next()
+		case p.accept("DEFERRABLE"), p.acceptSequence("NOT", "DEFERRABLE"):
+		default:
+			return fk, nil
+		}
+	}
+}
+
+// createIndex parses a `CREATE INDEX` statement, after the `INDEX` keyword. Only the unique indexes affect the schema,
+// marking their columns as unique.
+func (p *parser) createIndex(s *Schema, isUnique bool) error {
+	if !isUnique {
+		return nil
+	}
+
+	indexName := ""
+	for !p.done() && !p.accept("ON") {
+		if tk := p.next(); !tk.is("CONCURRENTLY", "IF", "NOT", "EXISTS", ".") {
+			indexName = tk.value
+		}
+	}
+	p.accept("ONLY")
+
+	name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+
+	if p.accept("USING") {
+		p.next()
+	}
+
+	columns, err := p.columnList()
+	if err != nil {
+		return err
+	}
+
+	if tb, found := s.table(name); found {
+		tb.uniqueKeys = append(tb.uniqueKeys, constraint{name: indexName, columns: columns})
+	}
+
+	return nil
+}
+
+// commentOn parses a `COMMENT ON TABLE` or `COMMENT ON COLUMN` statement, after the `ON` keyword, describing the
+// respective table or column.
+func (p *parser) commentOn(s *Schema) error {
+	isColumn := p.accept("COLUMN")
+	if !isColumn && !p.accept("TABLE") {
+		return nil
+	}
+
+	names, err := p.nameParts()
+	if err != nil {
+		return err
+	}
+
+	if !p.accept("IS") {
+		return fmt.Errorf("expected IS for the comment of %v", strings.Join(names, "."))
+	}
+	description := ""
+	if !p.accept("NULL") {
+		description = p.next().value
+	}
+
+	if !isColumn {
+		if tb, found := s.table(names[len(names)-1]); found {
+			tb.description = description
+		}
+		return nil
+	}
+
+	if len(names) < 2 {
+		return fmt.Errorf("expected the table of column %v", names[0])
+	}
+
+	if tb, found := s.table(names[len(names)-2]); found {
+		if cl, found := tb.column(names[len(names)-1]); found {
+			cl.description = description
+		}
+	}
+
+	return nil
+}
+
+// dataType parses the data type of a column definition (e.g. `varchar(255)` or `timestamp with time zone`), up to
+// its first constraint.
+func (p *parser) dataType() string {
+	var tokens []token
+	depth := 0
+	for !p.done() {
+		tk := p.peek()
+		if depth == 0 && (tk.is(",", ")") || tk.is(columnConstraintKeywords...) || p.isCharacterSet()) {
+			break
+		}
+
+		if tk.is("(") {
+			depth++
+		}
+		if tk.is(")") {
+			depth--
+		}
+		tokens = append(tokens, p.next())
+	}
+
+	return strings.ToLower(render(tokens))
+}
+
+// expression parses an expression (e.g. the default value of a column), up to the next column constraint. The first
+// token always belongs to the expression, even if it is a keyword (e.g. `DEFAULT NULL`).
+func (p *parser) expression() string {
+	var tokens []token
+	depth := 0
+	for !p.done() {
+		tk := p.peek()
+		if depth == 0 && (tk.is(",", ")") || (len(tokens) > 0 && tk.is(columnConstraintKeywords...))) {
+			break
+		}
+
+		if tk.is("(") {
+			depth++
+		}
+		if tk.is(")") {
+			depth--
+		}
+		tokens = append(tokens, p.next())
+	}
+
+	return render(tokens)
+}
+
+// isCharacterSet checks whether the next tokens declare the character set of a column, which is not part of its data
+// type (unlike `character varying`).
+func (p *parser) isCharacterSet() bool {
+	return p.peek().is("CHARACTER") && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].is("SET")
+}
+
+// columnList parses a parenthesized list of columns (e.g. `(a, b DESC)`), ignoring their ordering or length.
+func (p *parser) columnList() ([]string, error) {
+	if !p.accept("(") {
+		return nil, fmt.Errorf("expected a list of columns instead of %q", p.peek().value)
+	}
+
+	var columns []string
+	for !p.accept(")") {
+		name, err := p.identifier()
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, name)
+
+		for !p.done() && !p.peek().is(",", ")") {
+			p.next()
+			p.skipGroup()
+		}
+		p.accept(",")
+	}
+
+	return columns, nil
+}
+
+// qualifiedName parses a possibly qualified name (e.g. `public.users`), returning its last part.
+func (p *parser) qualifiedName() (string, error) {
+	names, err := p.nameParts()
+	if err != nil {
+		return "", err
+	}
+
+	return names[len(names)-1], nil
+}
+
+// nameParts parses the parts of a possibly qualified name (e.g. `public.users.email`).
+func (p *parser) nameParts() ([]string, error) {
+	var names []string
+	for {
+		name, err := p.identifier()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+
+		if !p.accept(".") {
+			return names, nil
+		}
+	}
+}
+
+// identifier parses a plain or quoted identifier.
+func (p *parser) identifier() (string, error) {
+	tk := p.peek()
+	if p.done() || (tk.kind != tokenWord && tk.kind != tokenQuoted) {
+		return "", fmt.Errorf("expected an identifier instead of %q", tk.value)
+	}
+
+	return p.next().value, nil
+}
+
+// skipGroup skips a parenthesized group of tokens, if the next token opens one.
+func (p *parser) skipGroup() {
+	if !p.peek().is("(") {
+		return
+	}
+
+	depth := 0
+	for !p.done() {
+		tk := p.next()
+		if tk.is("(") {
+			depth++
+		}
+		if tk.is(")") {
+			depth--
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// skipItem skips the rest of an item of a table definition, up to the next comma or the end of the definition.
+func (p *parser) skipItem() {
+	for !p.done() && !p.peek().is(",", ")") {
+		if p.peek().is("(") {
+			p.skipGroup()
+			continue
+		}
+		p.next()
+	}
+}
+
+// accept consumes the next token if it is one of the provided keywords or symbols.
+func (p *parser) accept(values ...string) bool {
+	if p.done() || !p.peek().is(values...) {
+		return false
+	}
+
+	p.pos++
+	return true
+}
+
+// acceptSequence consumes the next tokens if they are the provided sequence of keywords or symbols.
+func (p *parser) acceptSequence(values ...string) bool {
+	if p.pos+len(values) > len(p.tokens) {
+		return false
+	}
+
+	for idx, value := range values {
+		if !p.tokens[p.pos+idx].is(value) {
+			return false
+		}
+	}
+
+	p.pos += len(values)
+	return true
+}
+
+// peek returns the next token without consuming it, or an empty token at the end of the statement.
+func (p *parser) peek() token {
+	if p.done() {
+		return token{}
+	}
+
+	return p.tokens[p.pos]
+}
+
+// peekAt returns the token at the provided offset from the next one without consuming it, or an empty token past the
+// end of the statement.
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return token{}
+	}
+
+	return p.tokens[p.pos+offset]
+}
+
+// next consumes and returns the next token, or an empty token at the end of the statement.
+func (p *parser) next() token {
+	tk := p.peek()
+	if !p.done() {
+		p.pos++
+	}
+
+	return tk
+}
+
+// done checks whether all the tokens of the statement have been consumed.
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// render joins tokens back to sql, separating the words but not the symbols (e.g. `numeric(10,2)` or `now()`).
+func render(tokens []token) string {
+	var builder strings.Builder
+	for idx, tk := range tokens {
+		value := tk.value
+		switch tk.kind {
+		case tokenString:
+			value = fmt.Sprintf("'%v'", strings.ReplaceAll(tk.value, "'", "''"))
+		case tokenQuoted:
+			value = fmt.Sprintf("%q", tk.value)
+		}
+
+		if idx > 0 && tk.kind != tokenSymbol && tokens[idx-1].kind != tokenSymbol {
+			builder.WriteString(" ")
+		}
+		builder.WriteString(value)
+	}
+
+	return builder.String()
+}
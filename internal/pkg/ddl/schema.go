@@ -0,0 +1,394 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+)
+
+// Schema describes the tables defined by sql statements, applied in order.
+type Schema struct {
+	tables []*table
+}
+
+// table describes a table of the schema alongside with its constraints.
+type table struct {
+	name        string
+	description string
+	columns     []*column
+
+	primaryKey     []string
+	primaryKeyName string
+	uniqueKeys     []constraint
+	foreignKeys    []foreignKey
+}
+
+// column describes a column of a table of the schema.
+type column struct {
+	name         string
+	dataType     string
+	defaultValue string
+	description  string
+	isNotNull    bool
+}
+
+// constraint describes a named (or unnamed) constraint over some columns of a table.
+type constraint struct {
+	name    string
+	columns []string
+}
+
+// foreignKey describes a foreign key constraint of a table, referring to the columns of another table. If no columns
+// are referred to, the foreign key refers to the primary key of the referenced table.
+type foreignKey struct {
+	constraint
+	referencedTable   string
+	referencedColumns []string
+}
+
+// NewSchema creates and returns a new, empty schema.
+func NewSchema() *Schema {
+	return &Schema{}
+}
+
+// Apply parses the sql statements of the content and applies them to the schema in order. The statements that do not
+// affect the tables (e.g. inserts or functions) are ignored.
+func (s *Schema) Apply(content string) error {
+	statements, err := tokenize(content)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		p := &parser{tokens: statement}
+		err := p.apply(s)
+		if err != nil {
+			return fmt.Errorf("line %v: %v", statement[0].line, err)
+		}
+	}
+
+	return nil
+}
+
+// Tables returns the tables of the schema, with their columns marked as primary and foreign keys. The columns of a
+// composite foreign key share the name of its constraint, while the foreign keys referring to tables missing from the
+// schema are omitted.
+func (s *Schema) Tables() []domain.Table {
+	var tableList []domain.Table
+	for _, tb := range s.tables {
+		var columnList []domain.Column
+		for _, cl := range tb.columns {
+			isPrimaryKey := containsName(tb.primaryKey, cl.name)
+			columnList = append(columnList, domain.Column{
+				Name:         cl.name,
+				Type:         cl.dataType,
+				IsPrimaryKey: isPrimaryKey,
+				IsUnique:     tb.isUnique(cl.name),
+				IsNullable:   !cl.isNotNull && !isPrimaryKey,
+				Default:      cl.defaultValue,
+				Description:  cl.description,
+			})
+		}
+
+		for _, fk := range tb.foreignKeys {
+			s.markForeignKey(tb, fk, columnList)
+		}
+
+		tableList = append(tableList, domain.Table{
+			Name:        tb.name,
+			ColumnList:  columnList,
+			Description: tb.description,
+		})
+	}
+
+	return tableList
+}
+
+// markForeignKey marks the columns of a foreign key, referring to the respective columns of the referenced table.
+func (s *Schema) markForeignKey(tb *table, fk foreignKey, columnList []domain.Column) {
+	referencedTable, found := s.table(fk.referencedTable)
+	if !found {
+		return
+	}
+
+	referencedColumns := fk.referencedColumns
+	if len(referencedColumns) == 0 {
+		referencedColumns = referencedTable.primaryKey
+	}
+	if len(referencedColumns) != len(fk.columns) {
+		return
+	}
+
+	foreignKeyName := ""
+	if len(fk.columns) > 1 {
+		foreignKeyName = fk.name
+		if foreignKeyName == "" {
+			foreignKeyName = fmt.Sprintf("fk_%v_%v", tb.name, strings.Join(fk.columns, "_"))
+		}
+	}
+
+	for idx, columnName := range fk.columns {
+		for clIdx := range columnList {
+			cl := &columnList[clIdx]
+			if !strings.EqualFold(cl.Name, columnName) || cl.ReferencedTable != "" {
+				continue
+			}
+
+			cl.IsForeignKey = true
+			cl.ReferencedTable = referencedTable.name
+			cl.ReferencedColumn = referencedColumns[idx]
+			cl.ForeignKeyName = foreignKeyName
+		}
+	}
+}
+
+// table returns the table of the schema with the provided name, matched case insensitively.
+func (s *Schema) table(name string) (*table, bool) {
+	for _, tb := range s.tables {
+		if strings.EqualFold(tb.name, name) {
+			return tb, true
+		}
+	}
+
+	return nil, false
+}
+
+// dropTable drops the table with the provided name alongside with the foreign keys referring to it, if it exists.
+func (s *Schema) dropTable(name string) bool {
+	var tables []*table
+	for _, tb := range s.tables {
+		if !strings.EqualFold(tb.name, name) {
+			tables = append(tables, tb)
+		}
+	}
+
+	if len(tables) == len(s.tables) {
+		return false
+	}
+	s.tables = tables
+
+	for _, tb := range s.tables {
+		var foreignKeys []foreignKey
+		for _, fk := range tb.foreignKeys {
+			if !strings.EqualFold(fk.referencedTable, name) {
+				foreignKeys = append(foreignKeys, fk)
+			}
+		}
+		tb.foreignKeys = foreignKeys
+	}
+
+	return true
+}
+
+// renameTable renames a table, along with the foreign keys referring to it.
+func (s *Schema) renameTable(tb *table, name string) error {
+	if existing, found := s.table(name); found && existing != tb {
+		return fmt.Errorf("table %v already exists", name)
+	}
+
+	for _, other := range s.tables {
+		for idx := range other.foreignKeys {
+			if strings.EqualFold(other.foreignKeys[idx].referencedTable, tb.name) {
+				other.foreignKeys[idx].referencedTable = name
+			}
+		}
+	}
+	tb.name = name
+
+	return nil
+}
+
+// dropColumn drops a column of a table, if it exists, alongside with the constraints it is part of and the foreign keys
+// referring to it.
+func (s *Schema) dropColumn(tb *table, name string) bool {
+	idx := tb.columnIndex(name)
+	if idx < 0 {
+		return false
+	}
+	tb.columns = append(tb.columns[:idx], tb.columns[idx+1:]...)
+
+	if containsName(tb.primaryKey, name) {
+		tb.primaryKey = nil
+		tb.primaryKeyName = ""
+	}
+
+	var uniqueKeys []constraint
+	for _, uniqueKey := range tb.uniqueKeys {
+		if !containsName(uniqueKey.columns, name) {
+			uniqueKeys = append(uniqueKeys, uniqueKey)
+		}
+	}
+	tb.uniqueKeys = uniqueKeys
+
+	for _, other := range s.tables {
+		var foreignKeys []foreignKey
+		for _, fk := range other.foreignKeys {
+			isOwn := other == tb && containsName(fk.columns, name)
+			isReferred := strings.EqualFold(fk.referencedTable, tb.name) && containsName(fk.referencedColumns, name)
+			if !isOwn && !isReferred {
+				foreignKeys = append(foreignKeys, fk)
+			}
+		}
+		other.foreignKeys = foreignKeys
+	}
+
+	return true
+}
+
+// renameColumn renames a column of a table, if it exists, along with the constraints it is part of and the foreign keys
+// referring to it.
+func (s *Schema) renameColumn(tb *table, oldName, newName string) bool {
+	cl, found := tb.column(oldName)
+	if !found {
+		return false
+	}
+	cl.name = newName
+
+	renameName(tb.primaryKey, oldName, newName)
+	for _, uniqueKey := range tb.uniqueKeys {
+		renameName(uniqueKey.columns, oldName, newName)
+	}
+
+	for _, other := range s.tables {
+		for _, fk := range other.foreignKeys {
+			if other == tb {
+				renameName(fk.columns, oldName, newName)
+			}
+			if strings.EqualFold(fk.referencedTable, tb.name) {
+				renameName(fk.referencedColumns, oldName, newName)
+			}
+		}
+	}
+
+	return true
+}
+
+// renameName renames the matching names of a list in place.
+func renameName(names []string, oldName, newName string) {
+	for idx := range names {
+		if strings.EqualFold(names[idx], oldName) {
+			names[idx] = newName
+		}
+	}
+}
+
+// column returns the column of the table with the provided name, matched case insensitively.
+func (t *table) column(name string) (*column, bool) {
+	for _, cl := range t.columns {
+		if strings.EqualFold(cl.name, name) {
+			return cl, true
+		}
+	}
+
+	return nil, false
+}
+
+// columnIndex returns the index of the column of the table with the provided name, or -1 if it does not exist.
+func (t *table) columnIndex(name string) int {
+	for idx, cl := range t.columns {
+		if strings.EqualFold(cl.name, name) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// dropConstraint drops the primary key, the unique key or the foreign key of the table with the provided name, if it
+// exists.
+//
+// The unnamed constraints are matched by the names that postgres gives them (e.g. `users_pkey`, `users_email_key` or
+// `orders_customer_id_fkey`), since the migrations usually drop them by those.
+func (t *table) dropConstraint(name string) bool {
+	isDropped := t.dropUniqueKey(name)
+
+	if t.primaryKey != nil && matchesConstraint(constraint{name: t.primaryKeyName}, name, t.name+"_pkey") {
+		t.primaryKey = nil
+		t.primaryKeyName = ""
+		isDropped = true
+	}
+
+	var foreignKeys []foreignKey
+	for _, fk := range t.foreignKeys {
+		if matchesConstraint(fk.constraint, name, t.defaultConstraintName(fk.columns, "fkey")) {
+			isDropped = true
+			continue
+		}
+		foreignKeys = append(foreignKeys, fk)
+	}
+	t.foreignKeys = foreignKeys
+
+	return isDropped
+}
+
+// dropUniqueKey drops the unique key (or unique index) of the table with the provided name, if it exists.
+func (t *table) dropUniqueKey(name string) bool {
+	var uniqueKeys []constraint
+	for _, uniqueKey := range t.uniqueKeys {
+		if !matchesConstraint(uniqueKey, name, t.defaultConstraintName(uniqueKey.columns, "key")) {
+			uniqueKeys = append(uniqueKeys, uniqueKey)
+		}
+	}
+
+	isDropped := len(uniqueKeys) != len(t.uniqueKeys)
+	t.uniqueKeys = uniqueKeys
+
+	return isDropped
+}
+
+// renameConstraint renames the primary key, the unique key or the foreign key of the table with the provided name.
+func (t *table) renameConstraint(oldName, newName string) {
+	if t.primaryKey != nil && matchesConstraint(constraint{name: t.primaryKeyName}, oldName, t.name+"_pkey") {
+		t.primaryKeyName = newName
+	}
+
+	for idx, uniqueKey := range t.uniqueKeys {
+		if matchesConstraint(uniqueKey, oldName, t.defaultConstraintName(uniqueKey.columns, "key")) {
+			t.uniqueKeys[idx].name = newName
+		}
+	}
+
+	for idx, fk := range t.foreignKeys {
+		if matchesConstraint(fk.constraint, oldName, t.defaultConstraintName(fk.columns, "fkey")) {
+			t.foreignKeys[idx].name = newName
+		}
+	}
+}
+
+// defaultConstraintName returns the name that postgres gives to an unnamed constraint of the table over some columns
+// (e.g. `orders_customer_id_fkey`).
+func (t *table) defaultConstraintName(columns []string, suffix string) string {
+	return fmt.Sprintf("%v_%v_%v", t.name, strings.Join(columns, "_"), suffix)
+}
+
+// matchesConstraint checks whether a constraint has the provided name, or is unnamed with the provided default name.
+func matchesConstraint(c constraint, name, defaultName string) bool {
+	if c.name != "" {
+		return strings.EqualFold(c.name, name)
+	}
+
+	return strings.EqualFold(defaultName, name)
+}
+
+// isUnique checks whether a column is unique on its own, being the single column of a unique key.
+func (t *table) isUnique(columnName string) bool {
+	for _, uniqueKey := range t.uniqueKeys {
+		if len(uniqueKey.columns) == 1 && strings.EqualFold(uniqueKey.columns[0], columnName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsName checks whether a list of names contains the provided one, case insensitively.
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,271 @@
+package inference
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	// RuleSuffix infers a reference when a column is named after a table followed by the `_id` suffix
+	// (e.g. `user_id` or `author_user_id` refer to `user`).
+	RuleSuffix = "suffix"
+	// RuleContains infers a reference when a column name contains the name of a table (e.g. `user_id` or `username`
+	// refer to `user`).
+	RuleContains = "contains"
+	// RuleSelf infers a reference of a table to itself when a column is named after a self referencing role followed
+	// by the `_id` suffix (e.g. `parent_id` or `manager_id`).
+	RuleSelf = "self"
+	// RuleAllow describes the references explicitly provided through the allow list.
+	RuleAllow = "allow"
+)
+
+// defaultRules describes the rules applied when none is provided.
+var defaultRules = []string{RuleSuffix, RuleSelf}
+
+// selfReferencingRoles describes the roles that a column referring to its own table is commonly named after.
+var selfReferencingRoles = []string{"parent", "manager", "supervisor", "predecessor", "successor", "previous", "next", "original"}
+
+// Reference describes the table that a column has been inferred to refer to, alongside with the rule that inferred it.
+type Reference struct {
+	Table string
+	Rule  string
+}
+
+// Rule describes a way of inferring whether a column refers to a table.
+type Rule interface {
+	// Name returns the name of the rule, as recorded on the inferred references.
+	Name() string
+	// Match checks whether the provided column refers to the provided table.
+	Match(column, table string) bool
+	// MatchSelf checks whether the provided column of a table refers to the table itself.
+	MatchSelf(column, table string) bool
+}
+
+type inflector interface {
+	GetCaseOfString(initialValue, convertToCase string) string
+	GetValueCount(isPlural bool, initialValue string) string
+}
+
+// Engine describes the inference engine of the foreign key references.
+type Engine struct {
+	inflector inflector
+	rules     []Rule
+	allowList map[string]string
+	denyList  []string
+}
+
+// New creates and returns a new inference engine applying the provided rules, in order. The rules match the columns
+// against the table names and their singular, as well as their plural when the tables are referred to in plural
+// (e.g. `users_id` refers to `user`).
+//
+// The allow list entries define references explicitly in the `table.column=referenced_table` format, while the deny
+// list entries are patterns (e.g. `user.username` or `*.user_agent`) of the columns that never refer to a table.
+func New(inflector inflector, tablesInPlural bool, ruleNames, allowList, denyList []string) (*Engine, error) {
+	if len(ruleNames) == 0 {
+		ruleNames = defaultRules
+	}
+
+	engine := &Engine{
+		inflector: inflector,
+		allowList: map[string]string{},
+		denyList:  denyList,
+	}
+
+	for _, name := range ruleNames {
+		switch name {
+		case RuleSuffix:
+			engine.rules = append(engine.rules, suffixRule{inflector: inflector, plural: tablesInPlural, suffix: "_id"})
+		case RuleContains:
+			engine.rules = append(engine.rules, containsRule{inflector: inflector, plural: tablesInPlural})
+		case RuleSelf:
+			engine.rules = append(engine.rules, selfRule{roles: selfReferencingRoles, suffix: "_id"})
+		default:
+			return nil, fmt.Errorf("unknown foreign key rule '%v'", name)
+		}
+	}
+
+	for _, entry := range allowList {
+		column, table, found := strings.Cut(entry, "=")
+		if !found || !strings.Contains(column, ".") || table == "" {
+			return nil, fmt.Errorf("invalid foreign key allow list entry '%v', expected 'table.column=referenced_table'", entry)
+		}
+		engine.allowList[strings.TrimSpace(column)] = strings.TrimSpace(table)
+	}
+
+	for _, pattern := range denyList {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid foreign key deny list pattern '%v' : %v", pattern, err)
+		}
+	}
+
+	return engine, nil
+}
+
+// Infer returns the table that a column of a table refers to, if any.
+//
+// The deny list has the highest priority, followed by the allow list and then the rules in the order provided. The
+// allow list entries referring to a table that does not exist are ignored, leaving the column to the rules. When
+// a rule matches several tables (e.g. `user_role_id` matches both `user_role` and `role`), the table with the longest
+// name is preferred, being the most specific one.
+//
+// A column refers to its own table only when named after a role (e.g. `parent_category_id` or `manager_id`), since a
+// column named after its own table (e.g. `category_id` of `category`) is most likely the identifier of the table.
+func (e *Engine) Infer(tableName, columnName string, tableNames []string) (Reference, bool) {
+	qualifiedName := fmt.Sprintf("%v.%v", tableName, columnName)
+	if e.isDenied(qualifiedName, columnName) {
+		return Reference{}, false
+	}
+
+	if table, found := e.allowList[qualifiedName]; found {
+		for _, candidate := range tableNames {
+			if strings.EqualFold(candidate, table) {
+				return Reference{Table: candidate, Rule: RuleAllow}, true
+			}
+		}
+	}
+
+	column := e.inflector.GetCaseOfString(columnName, "snake_case")
+	for _, rule := range e.rules {
+		var matches []string
+		for _, candidate := range tableNames {
+			candidateName := e.inflector.GetCaseOfString(candidate, "snake_case")
+			if candidate == tableName && rule.MatchSelf(column, candidateName) || candidate != tableName && rule.Match(column, candidateName) {
+				matches = append(matches, candidate)
+			}
+		}
+
+		if len(matches) == 0 {
+			continue
+		}
+
+		sort.SliceStable(matches, func(i, j int) bool {
+			if len(matches[i]) != len(matches[j]) {
+				return len(matches[i]) > len(matches[j])
+			}
+			return matches[i] < matches[j]
+		})
+
+		return Reference{Table: matches[0], Rule: rule.Name()}, true
+	}
+
+	return Reference{}, false
+}
+
+// isDenied checks whether a column matches any of the patterns of the deny list, either with its table or on its own.
+func (e *Engine) isDenied(qualifiedName, columnName string) bool {
+	for _, pattern := range e.denyList {
+		if matched, _ := path.Match(pattern, qualifiedName); matched {
+			return true
+		}
+
+		if matched, _ := path.Match(pattern, columnName); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getTableNameForms returns the name of a table, its singular and, when referred to in plural, its plural.
+func getTableNameForms(inflector inflector, table string, plural bool) []string {
+	forms := []string{table, inflector.GetValueCount(false, table)}
+	if plural {
+		forms = append(forms, inflector.GetValueCount(true, table))
+	}
+
+	return forms
+}
+
+// suffixRule matches the columns named after the (singular) name of a table followed by a suffix, optionally
+// prefixed by a role (e.g. `user_id` or `author_user_id`).
+type suffixRule struct {
+	inflector inflector
+	plural    bool
+	suffix    string
+}
+
+// Name returns the name of the rule.
+func (r suffixRule) Name() string {
+	return RuleSuffix
+}
+
+// Match checks whether the provided column refers to the provided table.
+func (r suffixRule) Match(column, table string) bool {
+	for _, name := range getTableNameForms(r.inflector, table, r.plural) {
+		if column == name+r.suffix || strings.HasSuffix(column, "_"+name+r.suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchSelf checks whether the provided column of a table refers to the table itself, being prefixed by a role
+// (e.g. `parent_category_id` of `category`).
+func (r suffixRule) MatchSelf(column, table string) bool {
+	for _, name := range getTableNameForms(r.inflector, table, r.plural) {
+		if strings.HasSuffix(column, "_"+name+r.suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsRule matches the columns whose name contains the (singular) name of a table.
+type containsRule struct {
+	inflector inflector
+	plural    bool
+}
+
+// Name returns the name of the rule.
+func (r containsRule) Name() string {
+	return RuleContains
+}
+
+// Match checks whether the provided column refers to the provided table.
+func (r containsRule) Match(column, table string) bool {
+	for _, name := range getTableNameForms(r.inflector, table, r.plural) {
+		if strings.Contains(column, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchSelf checks whether the provided column of a table refers to the table itself, which is never the case for
+// the columns merely containing the name of their own table (e.g. `category_name` of `category`).
+func (r containsRule) MatchSelf(column, table string) bool {
+	return false
+}
+
+// selfRule matches the columns of a table named after a self referencing role followed by a suffix (e.g. `parent_id`
+// or `manager_id`), referring to the table itself.
+type selfRule struct {
+	roles  []string
+	suffix string
+}
+
+// Name returns the name of the rule.
+func (r selfRule) Name() string {
+	return RuleSelf
+}
+
+// Match checks whether the provided column refers to the provided table, which is never the case for other tables.
+func (r selfRule) Match(column, table string) bool {
+	return false
+}
+
+// MatchSelf checks whether the provided column of a table refers to the table itself.
+func (r selfRule) MatchSelf(column, table string) bool {
+	for _, role := range r.roles {
+		if column == role+r.suffix {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,216 @@
+package inference_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/eujoy/erbuilder/internal/pkg/inference"
+	"github.com/eujoy/erbuilder/internal/pkg/util"
+)
+
+func TestNew(t *testing.T) {
+	testCases := map[string]struct {
+		ruleNames   []string
+		allowList   []string
+		denyList    []string
+		expectError bool
+	}{
+		"Create an engine with the default rules": {
+			expectError: false,
+		},
+		"Create an engine with all the rules and lists": {
+			ruleNames:   []string{"suffix", "contains"},
+			allowList:   []string{"order.buyer=user"},
+			denyList:    []string{"*.user_agent"},
+			expectError: false,
+		},
+		"Attempt to create an engine with an unknown rule": {
+			ruleNames:   []string{"prefix"},
+			expectError: true,
+		},
+		"Attempt to create an engine with an invalid allow list entry": {
+			allowList:   []string{"buyer=user"},
+			expectError: true,
+		},
+		"Attempt to create an engine with an invalid deny list pattern": {
+			denyList:    []string{"[user"},
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualEngine, err := inference.New(util.New(), false, tc.ruleNames, tc.allowList, tc.denyList)
+			if tc.expectError && err == nil {
+				t.Errorf("Expected to get an error but got nil.")
+			}
+
+			if !tc.expectError && reflect.TypeOf(&inference.Engine{}) != reflect.TypeOf(actualEngine) {
+				t.Errorf("Expected to get type '%v' but got '%v'.", reflect.TypeOf(&inference.Engine{}), reflect.TypeOf(actualEngine))
+			}
+		})
+	}
+}
+
+func TestInfer(t *testing.T) {
+	tableNames := []string{"user", "role", "user_role", "orders", "session"}
+
+	testCases := map[string]struct {
+		tablesInPlural    bool
+		ruleNames         []string
+		allowList         []string
+		denyList          []string
+		inputTable        string
+		inputColumn       string
+		expectedReference inference.Reference
+		expectedFound     bool
+	}{
+		"Infer a reference by the suffix rule": {
+			inputTable:        "session",
+			inputColumn:       "user_id",
+			expectedReference: inference.Reference{Table: "user", Rule: "suffix"},
+			expectedFound:     true,
+		},
+		"Infer a reference by the suffix rule with a role prefix": {
+			inputTable:        "session",
+			inputColumn:       "author_user_id",
+			expectedReference: inference.Reference{Table: "user", Rule: "suffix"},
+			expectedFound:     true,
+		},
+		"Infer a reference by the suffix rule to a plural table name": {
+			inputTable:        "session",
+			inputColumn:       "order_id",
+			expectedReference: inference.Reference{Table: "orders", Rule: "suffix"},
+			expectedFound:     true,
+		},
+		"Infer a reference by the suffix rule to the plural of a table name": {
+			tablesInPlural:    true,
+			inputTable:        "session",
+			inputColumn:       "users_id",
+			expectedReference: inference.Reference{Table: "user", Rule: "suffix"},
+			expectedFound:     true,
+		},
+		"Do not infer a reference by the suffix rule to the plural of a table name when not in plural": {
+			inputTable:    "session",
+			inputColumn:   "users_id",
+			expectedFound: false,
+		},
+		"Infer a reference by the suffix rule in camel case": {
+			inputTable:        "session",
+			inputColumn:       "userId",
+			expectedReference: inference.Reference{Table: "user", Rule: "suffix"},
+			expectedFound:     true,
+		},
+		"Infer a reference to the table with the longest name when several match": {
+			inputTable:        "session",
+			inputColumn:       "user_role_id",
+			expectedReference: inference.Reference{Table: "user_role", Rule: "suffix"},
+			expectedFound:     true,
+		},
+		"Do not infer a reference for a column containing a table name by the suffix rule": {
+			inputTable:    "session",
+			inputColumn:   "username",
+			expectedFound: false,
+		},
+		"Do not infer a reference for a column with a table name in the middle by the suffix rule": {
+			inputTable:    "session",
+			inputColumn:   "superuser_flag",
+			expectedFound: false,
+		},
+		"Do not infer a reference to the table of the column": {
+			inputTable:    "user",
+			inputColumn:   "user_id",
+			expectedFound: false,
+		},
+		"Infer a reference to the table of the column by the suffix rule with a role prefix": {
+			inputTable:        "user",
+			inputColumn:       "referrer_user_id",
+			expectedReference: inference.Reference{Table: "user", Rule: "suffix"},
+			expectedFound:     true,
+		},
+		"Infer a reference to the table of the column by the self rule": {
+			inputTable:        "session",
+			inputColumn:       "parent_id",
+			expectedReference: inference.Reference{Table: "session", Rule: "self"},
+			expectedFound:     true,
+		},
+		"Do not infer a reference by the self rule when not enabled": {
+			ruleNames:     []string{"suffix"},
+			inputTable:    "session",
+			inputColumn:   "manager_id",
+			expectedFound: false,
+		},
+		"Do not infer a reference to the table of the column by the contains rule": {
+			ruleNames:     []string{"contains"},
+			inputTable:    "session",
+			inputColumn:   "session_token",
+			expectedFound: false,
+		},
+		"Infer a reference by the contains rule": {
+			ruleNames:         []string{"suffix", "contains"},
+			inputTable:        "session",
+			inputColumn:       "user_agent",
+			expectedReference: inference.Reference{Table: "user", Rule: "contains"},
+			expectedFound:     true,
+		},
+		"Infer a reference by the contains rule to the plural of a table name": {
+			tablesInPlural:    true,
+			ruleNames:         []string{"contains"},
+			inputTable:        "session",
+			inputColumn:       "roles_count",
+			expectedReference: inference.Reference{Table: "role", Rule: "contains"},
+			expectedFound:     true,
+		},
+		"Infer a reference by the allow list": {
+			allowList:         []string{"session.owner=user"},
+			inputTable:        "session",
+			inputColumn:       "owner",
+			expectedReference: inference.Reference{Table: "user", Rule: "allow"},
+			expectedFound:     true,
+		},
+		"Do not infer a reference by the allow list to a table that does not exist": {
+			allowList:     []string{"session.owner=usr"},
+			inputTable:    "session",
+			inputColumn:   "owner",
+			expectedFound: false,
+		},
+		"Infer a reference by the rules for a column of the allow list referring to a table that does not exist": {
+			allowList:         []string{"session.user_id=usr"},
+			inputTable:        "session",
+			inputColumn:       "user_id",
+			expectedReference: inference.Reference{Table: "user", Rule: "suffix"},
+			expectedFound:     true,
+		},
+		"Do not infer a reference for a column of the deny list": {
+			denyList:      []string{"session.user_id"},
+			inputTable:    "session",
+			inputColumn:   "user_id",
+			expectedFound: false,
+		},
+		"Do not infer a reference for a column matching a pattern of the deny list": {
+			ruleNames:     []string{"contains"},
+			denyList:      []string{"*_agent"},
+			inputTable:    "session",
+			inputColumn:   "user_agent",
+			expectedFound: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			engine, err := inference.New(util.New(), tc.tablesInPlural, tc.ruleNames, tc.allowList, tc.denyList)
+			if err != nil {
+				t.Fatalf("Expected to get nil as error but got '%v'.", err)
+			}
+
+			actualReference, actualFound := engine.Infer(tc.inputTable, tc.inputColumn, tableNames)
+			if tc.expectedFound != actualFound {
+				t.Errorf("Expected to get '%v' as found but got '%v'.", tc.expectedFound, actualFound)
+			}
+
+			if !reflect.DeepEqual(tc.expectedReference, actualReference) {
+				t.Errorf("Expected to get '%v' as response but got '%v'.", tc.expectedReference, actualReference)
+			}
+		})
+	}
+}
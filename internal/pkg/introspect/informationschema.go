@@ -0,0 +1,314 @@
+package introspect
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+
+	// the drivers of the databases that the information schema is read from.
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+)
+
+const (
+	constraintPrimaryKey = "PRIMARY KEY"
+	constraintUnique     = "UNIQUE"
+)
+
+// Queryer describes the connection that the catalog of a database is queried through, such as a *sql.DB.
+type Queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// informationSchemaQueries describes the queries of the information schema of a database, each one filtering by the
+// provided schema, or the current one if empty.
+//
+// The tables query returns the name and the comment of each table, the columns query the table, the name, the data
+// type, the nullability (`YES` or `NO`), the default value and the comment of each column, the keys query the table,
+// the constraint, the type (primary key or unique) and the column of each key column, and the foreign keys query the
+// table, the constraint, the column and the referenced table and column of each foreign key column.
+type informationSchemaQueries struct {
+	tables      string
+	columns     string
+	keys        string
+	foreignKeys string
+}
+
+// postgresQueries describes the queries of the information schema of postgres, reading the comments from `pg_catalog`.
+var postgresQueries = informationSchemaQueries{
+	tables: `
+		SELECT t.table_name,
+			COALESCE(pg_catalog.obj_description((quote_ident(t.table_schema) || '.' || quote_ident(t.table_name))::regclass, 'pg_class'), '')
+		FROM information_schema.tables t
+		WHERE t.table_schema = COALESCE(NULLIF($1, ''), current_schema()) AND t.table_type = 'BASE TABLE'
+		ORDER BY t.table_name`,
+	columns: `
+		SELECT c.table_name, c.column_name,
+			CASE
+				WHEN c.data_type = 'USER-DEFINED' THEN c.udt_name
+				WHEN c.data_type = 'ARRAY' THEN substr(c.udt_name, 2) || '[]'
+				WHEN c.character_maximum_length IS NOT NULL THEN c.data_type || '(' || c.character_maximum_length || ')'
+				WHEN c.data_type = 'numeric' AND c.numeric_precision IS NOT NULL THEN c.data_type || '(' || c.numeric_precision || ',' || c.numeric_scale || ')'
+				ELSE c.data_type
+			END,
+			c.is_nullable, COALESCE(c.column_default, ''),
+			COALESCE(pg_catalog.col_description((quote_ident(c.table_schema) || '.' || quote_ident(c.table_name))::regclass, c.ordinal_position), '')
+		FROM information_schema.columns c
+		WHERE c.table_schema = COALESCE(NULLIF($1, ''), current_schema())
+		ORDER BY c.table_name, c.ordinal_position`,
+	keys: `
+		SELECT tc.table_name, tc.constraint_name, tc.constraint_type, kcu.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name AND kcu.table_name = tc.table_name
+		WHERE tc.table_schema = COALESCE(NULLIF($1, ''), current_schema()) AND tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE')
+		ORDER BY tc.table_name, tc.constraint_name, kcu.ordinal_position`,
+	foreignKeys: `
+		SELECT tc.table_name, tc.constraint_name, kcu.column_name, ref.table_name, ref.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name AND kcu.table_name = tc.table_name
+		JOIN information_schema.referential_constraints rc
+			ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name
+		JOIN information_schema.key_column_usage ref
+			ON ref.constraint_schema = rc.unique_constraint_schema AND ref.constraint_name = rc.unique_constraint_name
+			AND ref.ordinal_position = kcu.position_in_unique_constraint
+		WHERE tc.table_schema = COALESCE(NULLIF($1, ''), current_schema()) AND tc.constraint_type = 'FOREIGN KEY'
+		ORDER BY tc.table_name, tc.constraint_name, kcu.ordinal_position`,
+}
+
+// mysqlQueries describes the queries of the information schema of mysql, where the schema is the database.
+var mysqlQueries = informationSchemaQueries{
+	tables: `
+		SELECT t.table_name, t.table_comment
+		FROM information_schema.tables t
+		WHERE t.table_schema = COALESCE(NULLIF(?, ''), DATABASE()) AND t.table_type = 'BASE TABLE'
+		ORDER BY t.table_name`,
+	columns: `
+		SELECT c.table_name, c.column_name, c.column_type, c.is_nullable, COALESCE(c.column_default, ''), c.column_comment
+		FROM information_schema.columns c
+		WHERE c.table_schema = COALESCE(NULLIF(?, ''), DATABASE())
+		ORDER BY c.table_name, c.ordinal_position`,
+	keys: `
+		SELECT tc.table_name, tc.constraint_name, tc.constraint_type, kcu.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name AND kcu.table_name = tc.table_name
+		WHERE tc.table_schema = COALESCE(NULLIF(?, ''), DATABASE()) AND tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE')
+		ORDER BY tc.table_name, tc.constraint_name, kcu.ordinal_position`,
+	foreignKeys: `
+		SELECT tc.table_name, tc.constraint_name, kcu.column_name, kcu.referenced_table_name, kcu.referenced_column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name AND kcu.table_name = tc.table_name
+		WHERE tc.table_schema = COALESCE(NULLIF(?, ''), DATABASE()) AND tc.constraint_type = 'FOREIGN KEY'
+		ORDER BY tc.table_name, tc.constraint_name, kcu.ordinal_position`,
+}
+
+// informationSchemaDrivers describes the queries of the information schema per database/sql driver.
+var informationSchemaDrivers = map[string]informationSchemaQueries{
+	"postgres": postgresQueries,
+	"mysql":    mysqlQueries,
+}
+
+// InformationSchema describes the reader of the tables of a database from its information schema.
+type InformationSchema struct {
+	db      Queryer
+	queries informationSchemaQueries
+}
+
+// NewInformationSchema creates and returns a new information schema reader for a database of the provided driver
+// (either `postgres` or `mysql`).
+func NewInformationSchema(db Queryer, driverName string) (*InformationSchema, error) {
+	queries, found := informationSchemaDrivers[driverName]
+	if !found {
+		return nil, fmt.Errorf("the information schema of driver %v is not supported", driverName)
+	}
+
+	return &InformationSchema{db: db, queries: queries}, nil
+}
+
+// Tables returns the tables of a schema ordered by their name, or of the current schema if empty. The columns are
+// marked as primary and foreign keys as declared in the database, while the columns of a composite foreign key share
+// the name of its constraint. The foreign keys referring to tables of other schemas are omitted.
+func (i *InformationSchema) Tables(schema string) ([]domain.Table, error) {
+	var tableList []domain.Table
+	tableIndexes := map[string]int{}
+	err := i.query(i.queries.tables, schema, func(rows *sql.Rows) error {
+		var table domain.Table
+		err := rows.Scan(&table.Name, &table.Description)
+		if err != nil {
+			return err
+		}
+
+		tableIndexes[table.Name] = len(tableList)
+		tableList = append(tableList, table)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.query(i.queries.columns, schema, func(rows *sql.Rows) error {
+		var tableName, isNullable string
+		var column domain.Column
+		err := rows.Scan(&tableName, &column.Name, &column.Type, &isNullable, &column.Default, &column.Description)
+		if err != nil {
+			return err
+		}
+
+		if idx, found := tableIndexes[tableName]; found {
+			column.IsNullable = isNullable == "YES"
+			tableList[idx].ColumnList = append(tableList[idx].ColumnList, column)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.markKeys(tableList, tableIndexes, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.markForeignKeys(tableList, tableIndexes, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return tableList, nil
+}
+
+// informationSchemaConstraint describes a constraint of a table, identifying the columns it consists of.
+type informationSchemaConstraint struct {
+	table string
+	name  string
+}
+
+// markKeys marks the columns of the primary keys of the tables, and the columns that are unique on their own.
+func (i *InformationSchema) markKeys(tableList []domain.Table, tableIndexes map[string]int, schema string) error {
+	var constraints []informationSchemaConstraint
+	constraintTypes := map[informationSchemaConstraint]string{}
+	constraintColumns := map[informationSchemaConstraint][]string{}
+	err := i.query(i.queries.keys, schema, func(rows *sql.Rows) error {
+		var constraint informationSchemaConstraint
+		var constraintType, columnName string
+		err := rows.Scan(&constraint.table, &constraint.name, &constraintType, &columnName)
+		if err != nil {
+			return err
+		}
+
+		if _, found := constraintTypes[constraint]; !found {
+			constraints = append(constraints, constraint)
+			constraintTypes[constraint] = constraintType
+		}
+		constraintColumns[constraint] = append(constraintColumns[constraint], columnName)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, constraint := range constraints {
+		idx, found := tableIndexes[constraint.table]
+		if !found {
+			continue
+		}
+
+		columnNames := constraintColumns[constraint]
+		for _, columnName := range columnNames {
+			for clIdx := range tableList[idx].ColumnList {
+				column := &tableList[idx].ColumnList[clIdx]
+				if column.Name != columnName {
+					continue
+				}
+
+				switch constraintTypes[constraint] {
+				case constraintPrimaryKey:
+					column.IsPrimaryKey = true
+					column.IsNullable = false
+				case constraintUnique:
+					column.IsUnique = column.IsUnique || len(columnNames) == 1
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// informationSchemaForeignKey describes a foreign key of a table, with the columns of the composite ones in order.
+type informationSchemaForeignKey struct {
+	table       string
+	fromColumns []string
+	toColumns   []string
+}
+
+// markForeignKeys marks the columns of the foreign keys of the tables, referring to the columns of the referenced
+// tables.
+func (i *InformationSchema) markForeignKeys(tableList []domain.Table, tableIndexes map[string]int, schema string) error {
+	var constraints []informationSchemaConstraint
+	foreignKeys := map[informationSchemaConstraint]*informationSchemaForeignKey{}
+	err := i.query(i.queries.foreignKeys, schema, func(rows *sql.Rows) error {
+		var constraint informationSchemaConstraint
+		var fromColumn, referencedTable, toColumn string
+		err := rows.Scan(&constraint.table, &constraint.name, &fromColumn, &referencedTable, &toColumn)
+		if err != nil {
+			return err
+		}
+
+		fk, found := foreignKeys[constraint]
+		if !found {
+			fk = &informationSchemaForeignKey{table: referencedTable}
+			constraints = append(constraints, constraint)
+			foreignKeys[constraint] = fk
+		}
+		fk.fromColumns = append(fk.fromColumns, fromColumn)
+		fk.toColumns = append(fk.toColumns, toColumn)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, constraint := range constraints {
+		idx, found := tableIndexes[constraint.table]
+		if !found {
+			continue
+		}
+
+		// the foreign keys referring to tables of other schemas are omitted.
+		fk := foreignKeys[constraint]
+		if _, found := tableIndexes[fk.table]; !found {
+			continue
+		}
+
+		foreignKeyName := ""
+		if len(fk.fromColumns) > 1 {
+			foreignKeyName = constraint.name
+		}
+		markForeignKey(&tableList[idx], fk.fromColumns, fk.table, fk.toColumns, foreignKeyName)
+	}
+
+	return nil
+}
+
+// query runs a query of the information schema for the provided schema, scanning each row of the result.
+func (i *InformationSchema) query(query, schema string, scan func(rows *sql.Rows) error) error {
+	rows, err := i.db.Query(query, schema)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := scan(rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,259 @@
+package introspect_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+	"github.com/eujoy/erbuilder/internal/pkg/introspect"
+)
+
+// fakeDriverName describes the name of the fake database/sql driver, answering the queries of the information schema
+// with the rows of its current catalog.
+const fakeDriverName = "introspect-fake"
+
+// fakeCatalog describes the rows returned for the queries containing each of the keys, and the arguments of the
+// queries run.
+type fakeCatalog struct {
+	mutex sync.Mutex
+	rows  map[string][][]driver.Value
+	args  []driver.Value
+}
+
+var catalog = &fakeCatalog{}
+
+func init() {
+	sql.Register(fakeDriverName, fakeDriver{})
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return fakeConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{query: query}, nil
+}
+
+func (fakeConn) Close() error {
+	return nil
+}
+
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions are not supported")
+}
+
+type fakeStmt struct {
+	query string
+}
+
+func (fakeStmt) Close() error {
+	return nil
+}
+
+func (fakeStmt) NumInput() int {
+	return -1
+}
+
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, fmt.Errorf("statements are not supported")
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+
+	catalog.args = append(catalog.args, args...)
+	for key, rows := range catalog.rows {
+		if strings.Contains(s.query, key) {
+			return &fakeRows{rows: rows}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected query %v", s.query)
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+	idx  int
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.idx >= len(r.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[r.idx])
+	r.idx++
+	return nil
+}
+
+// openFakeDatabase opens a database of the fake driver, answering the queries with the provided rows per table of the
+// information schema.
+func openFakeDatabase(t *testing.T, rows map[string][][]driver.Value) *sql.DB {
+	catalog.mutex.Lock()
+	catalog.rows = rows
+	catalog.args = nil
+	catalog.mutex.Unlock()
+
+	db, err := sql.Open(fakeDriverName, "")
+	if err != nil {
+		t.Fatalf("Expected to get no error but got '%v'.", err)
+	}
+
+	return db
+}
+
+func TestInformationSchema(t *testing.T) {
+	testCases := map[string]struct {
+		driverName     string
+		schema         string
+		rows           map[string][][]driver.Value
+		expectedTables []domain.Table
+	}{
+		"Read the tables of a postgres schema": {
+			driverName: "postgres",
+			schema:     "library",
+			rows: map[string][][]driver.Value{
+				"information_schema.tables": {
+					{"author", "The authors of the books."},
+					{"book", ""},
+				},
+				"information_schema.columns": {
+					{"author", "id", "bigint", "NO", "nextval('author_id_seq'::regclass)", ""},
+					{"author", "email", "character varying(255)", "NO", "", "The email of the author."},
+					{"book", "id", "bigint", "NO", "", ""},
+					{"book", "author_id", "bigint", "NO", "", ""},
+					{"book", "tags", "text[]", "YES", "", ""},
+				},
+				"'PRIMARY KEY', 'UNIQUE'": {
+					{"author", "author_pkey", "PRIMARY KEY", "id"},
+					{"author", "author_email_key", "UNIQUE", "email"},
+					{"book", "book_pkey", "PRIMARY KEY", "id"},
+				},
+				"'FOREIGN KEY'": {
+					{"book", "book_author_id_fkey", "author_id", "author", "id"},
+				},
+			},
+			expectedTables: []domain.Table{
+				{
+					Name:        "author",
+					Description: "The authors of the books.",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "bigint", IsPrimaryKey: true, Default: "nextval('author_id_seq'::regclass)"},
+						{Name: "email", Type: "character varying(255)", IsUnique: true, Description: "The email of the author."},
+					},
+				},
+				{
+					Name: "book",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "bigint", IsPrimaryKey: true},
+						{Name: "author_id", Type: "bigint", IsForeignKey: true, ReferencedTable: "author", ReferencedColumn: "id"},
+						{Name: "tags", Type: "text[]", IsNullable: true},
+					},
+				},
+			},
+		},
+		"Read the composite keys of a mysql database, omitting the foreign keys to other schemas": {
+			driverName: "mysql",
+			rows: map[string][][]driver.Value{
+				"information_schema.tables": {
+					{"order_line", ""},
+					{"shipment", ""},
+				},
+				"information_schema.columns": {
+					{"order_line", "order_id", "int", "NO", "", ""},
+					{"order_line", "line_no", "int", "NO", "", ""},
+					{"shipment", "id", "int", "NO", "", ""},
+					{"shipment", "order_id", "int", "NO", "", ""},
+					{"shipment", "line_no", "int", "NO", "", ""},
+					{"shipment", "carrier_id", "int", "YES", "", ""},
+				},
+				"'PRIMARY KEY', 'UNIQUE'": {
+					{"order_line", "PRIMARY", "PRIMARY KEY", "order_id"},
+					{"order_line", "PRIMARY", "PRIMARY KEY", "line_no"},
+					{"shipment", "PRIMARY", "PRIMARY KEY", "id"},
+					{"shipment", "shipment_order_uq", "UNIQUE", "order_id"},
+					{"shipment", "shipment_order_uq", "UNIQUE", "line_no"},
+				},
+				"'FOREIGN KEY'": {
+					{"shipment", "shipment_carrier_fk", "carrier_id", "carrier", "id"},
+					{"shipment", "shipment_order_line_fk", "order_id", "order_line", "order_id"},
+					{"shipment", "shipment_order_line_fk", "line_no", "order_line", "line_no"},
+				},
+			},
+			expectedTables: []domain.Table{
+				{
+					Name: "order_line",
+					ColumnList: []domain.Column{
+						{Name: "order_id", Type: "int", IsPrimaryKey: true},
+						{Name: "line_no", Type: "int", IsPrimaryKey: true},
+					},
+				},
+				{
+					Name: "shipment",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "int", IsPrimaryKey: true},
+						{Name: "order_id", Type: "int", IsForeignKey: true, ReferencedTable: "order_line", ReferencedColumn: "order_id", ForeignKeyName: "shipment_order_line_fk"},
+						{Name: "line_no", Type: "int", IsForeignKey: true, ReferencedTable: "order_line", ReferencedColumn: "line_no", ForeignKeyName: "shipment_order_line_fk"},
+						{Name: "carrier_id", Type: "int", IsNullable: true},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db := openFakeDatabase(t, tc.rows)
+			defer db.Close()
+
+			informationSchema, err := introspect.NewInformationSchema(db, tc.driverName)
+			if err != nil {
+				t.Fatalf("Expected to get no error but got '%v'.", err)
+			}
+
+			actualTables, err := informationSchema.Tables(tc.schema)
+			if err != nil {
+				t.Fatalf("Expected to get no error but got '%v'.", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedTables, actualTables) {
+				t.Errorf("Expected to get tables '%+v' but got '%+v'.", tc.expectedTables, actualTables)
+			}
+
+			for _, arg := range catalog.args {
+				if arg != tc.schema {
+					t.Errorf("Expected to filter by schema '%v' but got '%v'.", tc.schema, arg)
+				}
+			}
+		})
+	}
+}
+
+func TestNewInformationSchema(t *testing.T) {
+	_, err := introspect.NewInformationSchema(nil, "oracle")
+	if err == nil {
+		t.Errorf("Expected to get an error for an unsupported driver but got nil.")
+	}
+}
@@ -0,0 +1,247 @@
+package introspect
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+
+	"modernc.org/sqlite"
+)
+
+// SQLiteDriver describes the name of the database/sql driver of sqlite, as provided through the driver option.
+const SQLiteDriver = "sqlite3"
+
+func init() {
+	// the pure go driver (not requiring cgo) registers itself as `sqlite`, so it is registered under the name that the
+	// driver option accepts as well.
+	sql.Register(SQLiteDriver, &sqlite.Driver{})
+}
+
+// OpenSQLite opens a sqlite database file in read only mode, failing if the file does not exist instead of creating it.
+func OpenSQLite(path string) (*sql.DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	return sql.Open(SQLiteDriver, fmt.Sprintf("file:%v?mode=ro", path))
+}
+
+// sqliteForeignKey describes a foreign key of a sqlite table, as listed by `PRAGMA foreign_key_list`.
+type sqliteForeignKey struct {
+	table       string
+	fromColumns []string
+	toColumns   []string
+}
+
+// SQLite returns the tables of a sqlite database, in the order they have been created, reading `sqlite_master` and the
+// `table_info`, `foreign_key_list`, `index_list` and `index_info` pragmas of each table. The columns are marked as
+// primary and foreign keys as declared in the database, while the columns of a composite foreign key share its name.
+func SQLite(db *sql.DB) ([]domain.Table, error) {
+	tableNames, err := queryStrings(db, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY rowid")
+	if err != nil {
+		return nil, err
+	}
+
+	var tableList []domain.Table
+	primaryKeys := map[string][]string{}
+	for _, tableName := range tableNames {
+		columnList, err := getSQLiteColumns(db, tableName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, column := range columnList {
+			if column.IsPrimaryKey {
+				primaryKeys[tableName] = append(primaryKeys[tableName], column.Name)
+			}
+		}
+
+		tableList = append(tableList, domain.Table{Name: tableName, ColumnList: columnList})
+	}
+
+	for idx := range tableList {
+		table := &tableList[idx]
+		err := markSQLiteUniqueColumns(db, table)
+		if err != nil {
+			return nil, err
+		}
+
+		foreignKeys, err := getSQLiteForeignKeys(db, table.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for fkIdx, fk := range foreignKeys {
+			// the foreign keys referring to tables missing from the database are omitted.
+			referencedTable, found := getTableName(tableNames, fk.table)
+			if !found {
+				continue
+			}
+
+			toColumns := fk.toColumns
+			if toColumns == nil {
+				toColumns = primaryKeys[referencedTable]
+			}
+			if len(toColumns) != len(fk.fromColumns) {
+				continue
+			}
+
+			foreignKeyName := ""
+			if len(fk.fromColumns) > 1 {
+				foreignKeyName = fmt.Sprintf("fk_%v_%v", table.Name, fkIdx)
+			}
+			markForeignKey(table, fk.fromColumns, referencedTable, toColumns, foreignKeyName)
+		}
+	}
+
+	return tableList, nil
+}
+
+// getSQLiteColumns returns the columns of a sqlite table, as listed by `PRAGMA table_info`.
+func getSQLiteColumns(db *sql.DB, tableName string) ([]domain.Column, error) {
+	rows, err := db.Query("SELECT name, type, \"notnull\", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid", tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columnList []domain.Column
+	for rows.Next() {
+		var name, dataType string
+		var isNotNull bool
+		var defaultValue sql.NullString
+		var primaryKeyIndex int
+		err := rows.Scan(&name, &dataType, &isNotNull, &defaultValue, &primaryKeyIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		columnList = append(columnList, domain.Column{
+			Name:         name,
+			Type:         strings.ToLower(dataType),
+			IsPrimaryKey: primaryKeyIndex > 0,
+			IsNullable:   !isNotNull && primaryKeyIndex == 0,
+			Default:      defaultValue.String,
+		})
+	}
+
+	return columnList, rows.Err()
+}
+
+// markSQLiteUniqueColumns marks the columns of a sqlite table that are unique on their own, being the single column of
+// a unique index (either declared as a constraint or created as an index), as listed by `PRAGMA index_list`.
+func markSQLiteUniqueColumns(db *sql.DB, table *domain.Table) error {
+	indexNames, err := queryStrings(db, "SELECT name FROM pragma_index_list(?) WHERE \"unique\" = 1 AND origin != 'pk'", table.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, indexName := range indexNames {
+		columnNames, err := queryStrings(db, "SELECT name FROM pragma_index_info(?)", indexName)
+		if err != nil {
+			return err
+		}
+
+		if len(columnNames) != 1 {
+			continue
+		}
+
+		for idx := range table.ColumnList {
+			if table.ColumnList[idx].Name == columnNames[0] {
+				table.ColumnList[idx].IsUnique = true
+			}
+		}
+	}
+
+	return nil
+}
+
+// getSQLiteForeignKeys returns the foreign keys of a sqlite table, as listed by `PRAGMA foreign_key_list`, with the
+// columns of the composite ones in order. The referenced columns are nil for the foreign keys referring to the primary
+// key of the referenced table.
+func getSQLiteForeignKeys(db *sql.DB, tableName string) ([]sqliteForeignKey, error) {
+	rows, err := db.Query("SELECT id, \"table\", \"from\", \"to\" FROM pragma_foreign_key_list(?) ORDER BY id, seq", tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var foreignKeys []sqliteForeignKey
+	ids := map[int]int{}
+	for rows.Next() {
+		var id int
+		var referencedTable, fromColumn string
+		var toColumn sql.NullString
+		err := rows.Scan(&id, &referencedTable, &fromColumn, &toColumn)
+		if err != nil {
+			return nil, err
+		}
+
+		idx, found := ids[id]
+		if !found {
+			idx = len(foreignKeys)
+			ids[id] = idx
+			foreignKeys = append(foreignKeys, sqliteForeignKey{table: referencedTable})
+		}
+
+		foreignKeys[idx].fromColumns = append(foreignKeys[idx].fromColumns, fromColumn)
+		if toColumn.Valid {
+			foreignKeys[idx].toColumns = append(foreignKeys[idx].toColumns, toColumn.String)
+		}
+	}
+
+	return foreignKeys, rows.Err()
+}
+
+// queryStrings returns the single string column of the rows of a query.
+func queryStrings(db *sql.DB, query string, args ...interface{}) ([]string, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		err := rows.Scan(&value)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, rows.Err()
+}
+
+// markForeignKey marks the columns of a foreign key, referring to the respective columns of the referenced table. The
+// columns already referring to another table are kept as they are.
+func markForeignKey(table *domain.Table, fromColumns []string, referencedTable string, toColumns []string, foreignKeyName string) {
+	for idx, columnName := range fromColumns {
+		for clIdx := range table.ColumnList {
+			column := &table.ColumnList[clIdx]
+			if column.Name != columnName || column.ReferencedTable != "" {
+				continue
+			}
+
+			column.IsForeignKey = true
+			column.ReferencedTable = referencedTable
+			column.ReferencedColumn = toColumns[idx]
+			column.ForeignKeyName = foreignKeyName
+		}
+	}
+}
+
+// getTableName returns the name of a table as listed, since sqlite matches the table names case insensitively.
+func getTableName(tableNames []string, name string) (string, bool) {
+	for _, tableName := range tableNames {
+		if strings.EqualFold(tableName, name) {
+			return tableName, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,135 @@
+package introspect_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/eujoy/erbuilder/internal/domain"
+	"github.com/eujoy/erbuilder/internal/pkg/introspect"
+)
+
+func TestSQLite(t *testing.T) {
+	testCases := map[string]struct {
+		schema         string
+		expectedTables []domain.Table
+	}{
+		"Read the columns, the primary keys and the unique columns of the tables": {
+			schema: `
+				CREATE TABLE account (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					email VARCHAR(255) NOT NULL UNIQUE,
+					handle TEXT NOT NULL,
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+				);
+				CREATE UNIQUE INDEX account_handle_idx ON account (handle);`,
+			expectedTables: []domain.Table{
+				{
+					Name: "account",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "integer", IsPrimaryKey: true},
+						{Name: "email", Type: "varchar(255)", IsUnique: true},
+						{Name: "handle", Type: "text", IsUnique: true},
+						{Name: "created_at", Type: "datetime", IsNullable: true, Default: "CURRENT_TIMESTAMP"},
+					},
+				},
+			},
+		},
+		"Read the foreign keys, referring to the primary key when no columns are declared": {
+			schema: `
+				CREATE TABLE author (id INTEGER PRIMARY KEY);
+				CREATE TABLE book (
+					id INTEGER PRIMARY KEY,
+					author_id INTEGER NOT NULL REFERENCES Author,
+					editor_id INTEGER REFERENCES author (id),
+					publisher_id INTEGER REFERENCES publisher (id)
+				);`,
+			expectedTables: []domain.Table{
+				{
+					Name:       "author",
+					ColumnList: []domain.Column{{Name: "id", Type: "integer", IsPrimaryKey: true}},
+				},
+				{
+					Name: "book",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "integer", IsPrimaryKey: true},
+						{Name: "author_id", Type: "integer", IsForeignKey: true, ReferencedTable: "author", ReferencedColumn: "id"},
+						{Name: "editor_id", Type: "integer", IsForeignKey: true, IsNullable: true, ReferencedTable: "author", ReferencedColumn: "id"},
+						{Name: "publisher_id", Type: "integer", IsNullable: true},
+					},
+				},
+			},
+		},
+		"Read the composite primary and foreign keys": {
+			schema: `
+				CREATE TABLE order_line (order_id INTEGER, line_no INTEGER, PRIMARY KEY (order_id, line_no));
+				CREATE TABLE shipment (
+					id INTEGER PRIMARY KEY,
+					order_id INTEGER NOT NULL,
+					line_no INTEGER NOT NULL,
+					FOREIGN KEY (order_id, line_no) REFERENCES order_line (order_id, line_no)
+				);`,
+			expectedTables: []domain.Table{
+				{
+					Name: "order_line",
+					ColumnList: []domain.Column{
+						{Name: "order_id", Type: "integer", IsPrimaryKey: true},
+						{Name: "line_no", Type: "integer", IsPrimaryKey: true},
+					},
+				},
+				{
+					Name: "shipment",
+					ColumnList: []domain.Column{
+						{Name: "id", Type: "integer", IsPrimaryKey: true},
+						{Name: "order_id", Type: "integer", IsForeignKey: true, ReferencedTable: "order_line", ReferencedColumn: "order_id", ForeignKeyName: "fk_shipment_0"},
+						{Name: "line_no", Type: "integer", IsForeignKey: true, ReferencedTable: "order_line", ReferencedColumn: "line_no", ForeignKeyName: "fk_shipment_0"},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.db")
+			createSQLiteDatabase(t, path, tc.schema)
+
+			db, err := introspect.OpenSQLite(path)
+			if err != nil {
+				t.Fatalf("Expected to get no error but got '%v'.", err)
+			}
+			defer db.Close()
+
+			actualTables, err := introspect.SQLite(db)
+			if err != nil {
+				t.Fatalf("Expected to get no error but got '%v'.", err)
+			}
+
+			if !reflect.DeepEqual(tc.expectedTables, actualTables) {
+				t.Errorf("Expected to get tables '%+v' but got '%+v'.", tc.expectedTables, actualTables)
+			}
+		})
+	}
+}
+
+func TestOpenSQLite(t *testing.T) {
+	_, err := introspect.OpenSQLite(filepath.Join(t.TempDir(), "missing.db"))
+	if err == nil {
+		t.Errorf("Expected to get an error for a missing database file but got nil.")
+	}
+}
+
+// createSQLiteDatabase creates a sqlite database file with the provided schema.
+func createSQLiteDatabase(t *testing.T, path, schema string) {
+	db, err := sql.Open(introspect.SQLiteDriver, path)
+	if err != nil {
+		t.Fatalf("Expected to get no error but got '%v'.", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(schema)
+	if err != nil {
+		t.Fatalf("Expected to get no error but got '%v'.", err)
+	}
+}
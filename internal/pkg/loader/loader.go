@@ -0,0 +1,188 @@
+package loader
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// File describes a parsed go file alongside with the type information of the package it belongs to (if loaded).
+type File struct {
+	Path      string
+	Fset      *token.FileSet
+	Node      *ast.File
+	TypesInfo *types.Info
+
+	// PkgPath describes the import path of the package that the file belongs to, known only when loaded as a package.
+	PkgPath string
+}
+
+// Loader describes the loader package.
+type Loader struct{}
+
+// New creates and returns a new loader instance.
+func New() *Loader {
+	return &Loader{}
+}
+
+// LoadFiles parses each one of the provided files on its own, without resolving any type information.
+func (l *Loader) LoadFiles(fileList []string) ([]File, error) {
+	var files []File
+	for _, fl := range fileList {
+		fset := token.NewFileSet()
+		node, err := parser.ParseFile(fset, fl, nil, parser.ParseComments)
+		if err != nil {
+			return []File{}, err
+		}
+
+		files = append(files, File{
+			Path: fl,
+			Fset: fset,
+			Node: node,
+		})
+	}
+
+	return files, nil
+}
+
+// LoadPackages loads all the packages matching the provided patterns (e.g. ./internal/...) with full type information.
+func (l *Loader) LoadPackages(patterns []string) ([]File, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return []File{}, err
+	}
+
+	var files []File
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return []File{}, fmt.Errorf("failed to load package '%v' : %v", pkg.PkgPath, pkg.Errors[0])
+		}
+
+		for _, node := range pkg.Syntax {
+			files = append(files, File{
+				Path:      pkg.Fset.File(node.Pos()).Name(),
+				Fset:      pkg.Fset,
+				Node:      node,
+				TypesInfo: pkg.TypesInfo,
+				PkgPath:   pkg.PkgPath,
+			})
+		}
+	}
+
+	return files, nil
+}
+
+// ResolveType returns the type of the provided expression, resolved to its underlying type when type information is available.
+//
+// Without type information, the type is returned as written in the source code (e.g. `*string` or `sql.NullString`).
+func (f File) ResolveType(expr ast.Expr) string {
+	if f.TypesInfo == nil {
+		return types.ExprString(expr)
+	}
+
+	tp := f.TypesInfo.TypeOf(expr)
+	if tp == nil {
+		return types.ExprString(expr)
+	}
+
+	return TypeName(tp)
+}
+
+// DeclaredType returns the type of the provided expression as declared (e.g. `time.Duration` instead of `int64`), with
+// its package qualified name when type information is available.
+func (f File) DeclaredType(expr ast.Expr) string {
+	if f.TypesInfo == nil {
+		return types.ExprString(expr)
+	}
+
+	tp := f.TypesInfo.TypeOf(expr)
+	if tp == nil {
+		return types.ExprString(expr)
+	}
+
+	return DeclaredTypeName(tp)
+}
+
+// DeclaredTypeName returns the name of the provided type, qualified by the name of its package, without resolving it
+// (not even aliases, e.g. `json.RawMessage`).
+func DeclaredTypeName(tp types.Type) string {
+	return types.TypeString(tp, func(p *types.Package) string {
+		return p.Name()
+	})
+}
+
+// TypeName returns the name of the provided type, resolved to its underlying type.
+func TypeName(tp types.Type) string {
+	return types.TypeString(resolve(tp), func(p *types.Package) string {
+		return p.Name()
+	})
+}
+
+// resolve follows aliases and named types until reaching a type that can be mapped to a database data type.
+func resolve(tp types.Type) types.Type {
+	tp = types.Unalias(tp)
+
+	switch t := tp.(type) {
+	case *types.Named:
+		switch underlying := t.Underlying().(type) {
+		case *types.Basic:
+			return underlying
+		case *types.Struct:
+			if origin, found := originOf(t, underlying); found {
+				return origin
+			}
+			return t
+		case *types.Pointer, *types.Slice, *types.Array, *types.Map:
+			return resolve(underlying)
+		default:
+			return t
+		}
+	case *types.Pointer:
+		return types.NewPointer(resolve(t.Elem()))
+	case *types.Slice:
+		return types.NewSlice(resolve(t.Elem()))
+	case *types.Array:
+		return types.NewArray(resolve(t.Elem()), t.Len())
+	case *types.Map:
+		return types.NewMap(resolve(t.Key()), resolve(t.Elem()))
+	default:
+		return tp
+	}
+}
+
+// originOf finds the named type that a struct based type has been defined from (e.g. `type Stamp time.Time`).
+//
+// The fields of a struct keep a reference to the package that declared them, so the original type is searched
+// in the scope of that package.
+func originOf(named *types.Named, structType *types.Struct) (types.Type, bool) {
+	if structType.NumFields() == 0 {
+		return nil, false
+	}
+
+	fieldPkg := structType.Field(0).Pkg()
+	if fieldPkg == nil || fieldPkg == named.Obj().Pkg() {
+		return nil, false
+	}
+
+	scope := fieldPkg.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+
+		if types.Identical(typeName.Type().Underlying(), structType) {
+			return typeName.Type(), true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,106 @@
+package loader_test
+
+import (
+	"go/ast"
+	"reflect"
+	"testing"
+
+	"github.com/eujoy/erbuilder/internal/pkg/loader"
+)
+
+func TestNew(t *testing.T) {
+	actualLoader := loader.New()
+
+	if reflect.TypeOf(&loader.Loader{}) != reflect.TypeOf(actualLoader) {
+		t.Errorf("Expected to get type '%v' but got '%v'.", reflect.TypeOf(&loader.Loader{}), reflect.TypeOf(actualLoader))
+	}
+}
+
+func TestLoadFiles(t *testing.T) {
+	files, err := loader.New().LoadFiles([]string{"./../../../testdata/typed/models/models.go"})
+	if err != nil {
+		t.Errorf("Expected to get nil as error but got '%v'.", err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("Expected to get 1 file but got %v.", len(files))
+	}
+
+	if files[0].TypesInfo != nil {
+		t.Errorf("Expected to get no type information for a file parsed on its own.")
+	}
+
+	_, err = loader.New().LoadFiles([]string{"./../../../testdata/not-existing.go"})
+	if err == nil {
+		t.Errorf("Expected to get an error for a file that does not exist but got nil.")
+	}
+}
+
+func TestResolveType(t *testing.T) {
+	files, err := loader.New().LoadPackages([]string{"./../../../testdata/typed/..."})
+	if err != nil {
+		t.Fatalf("Expected to get nil as error but got '%v'.", err)
+	}
+
+	expectedTypes := map[string]string{
+		"ID":        "int",
+		"Name":      "string",
+		"Status":    "string",
+		"CreatedAt": "time.Time",
+		"UpdatedAt": "time.Time",
+		"Duration":  "int64",
+	}
+
+	actualTypes := map[string]string{}
+	for _, file := range files {
+		ast.Inspect(file.Node, func(n ast.Node) bool {
+			field, ok := n.(*ast.Field)
+			if ok && len(field.Names) > 0 && field.Tag != nil {
+				actualTypes[field.Names[0].Name] = file.ResolveType(field.Type)
+			}
+			return true
+		})
+	}
+
+	if !reflect.DeepEqual(expectedTypes, actualTypes) {
+		t.Errorf("Expected to get '%v' as response but got '%v'.", expectedTypes, actualTypes)
+	}
+}
+
+func TestDeclaredType(t *testing.T) {
+	files, err := loader.New().LoadPackages([]string{"./../../../testdata/typed/..."})
+	if err != nil {
+		t.Fatalf("Expected to get nil as error but got '%v'.", err)
+	}
+
+	expectedTypes := map[string]string{
+		"ID":        "int",
+		"Name":      "string",
+		"Status":    "models.Status",
+		"CreatedAt": "mytime.Stamp",
+		"UpdatedAt": "mytime.Moment",
+		"Duration":  "mytime.Seconds",
+	}
+
+	actualTypes := map[string]string{}
+	for _, file := range files {
+		ast.Inspect(file.Node, func(n ast.Node) bool {
+			field, ok := n.(*ast.Field)
+			if ok && len(field.Names) > 0 && field.Tag != nil {
+				actualTypes[field.Names[0].Name] = file.DeclaredType(field.Type)
+			}
+			return true
+		})
+	}
+
+	if !reflect.DeepEqual(expectedTypes, actualTypes) {
+		t.Errorf("Expected to get '%v' as response but got '%v'.", expectedTypes, actualTypes)
+	}
+}
+
+func TestLoadPackagesWithInvalidPattern(t *testing.T) {
+	_, err := loader.New().LoadPackages([]string{"./../../../testdata/not-existing"})
+	if err == nil {
+		t.Errorf("Expected to get an error for a package that does not exist but got nil.")
+	}
+}
@@ -0,0 +1,96 @@
+package tag
+
+import (
+	"strings"
+)
+
+// bunDialect describes the dialect of the bun tags.
+type bunDialect struct{}
+
+// Name returns the name of the dialect.
+func (d bunDialect) Name() string {
+	return "bun"
+}
+
+// Key returns the key of the struct tag that the dialect interprets.
+func (d bunDialect) Key() string {
+	return "bun"
+}
+
+// IncludesUntaggedFields defines whether the exported fields without a tag are columns as well.
+func (d bunDialect) IncludesUntaggedFields() bool {
+	return true
+}
+
+// PrimaryKeyField returns the name of the field which is the primary key by convention, if any.
+func (d bunDialect) PrimaryKeyField() string {
+	return ""
+}
+
+// ParseColumn interprets the tag value of a field as a column.
+func (d bunDialect) ParseColumn(value string) Column {
+	return ParseBun(value)
+}
+
+// ParseTable interprets the tag value of an embedded field (e.g. `bun:"table:users,alias:u"` on bun.BaseModel)
+// and returns the name of the table, if defined.
+func (d bunDialect) ParseTable(value string) (string, bool) {
+	for _, option := range strings.Split(value, ",") {
+		key, val := splitOption(option, ":")
+		if key == "table" && val != "" {
+			return val, true
+		}
+	}
+
+	return "", false
+}
+
+// ParseBun parses the value of a bun tag (e.g. `id,pk,autoincrement`) and returns the column details.
+//
+// As in bun, the columns are nullable unless defined as `notnull` or being a primary key.
+func ParseBun(value string) Column {
+	var column Column
+	for idx, option := range strings.Split(value, ",") {
+		key, val := splitOption(option, ":")
+		if idx == 0 && !strings.Contains(option, ":") {
+			if key == "-" {
+				column.Ignore = true
+			} else {
+				column.Name = key
+			}
+			continue
+		}
+
+		switch key {
+		case "pk":
+			column.IsPrimaryKey = true
+		case "notnull":
+			column.IsNotNull = true
+		case "unique":
+			column.IsUnique = true
+		case "type":
+			column.Type = val
+		case "default":
+			column.Default = val
+		case "table", "scanonly":
+			column.Ignore = true
+		case "rel":
+			column.Relation = val
+		case "m2m":
+			column.Relation = RelationManyToMany
+			column.JoinTable = val
+		case "join":
+			// composite joins are declared with several join options (e.g. `join:order_id=id,join:line_no=line_no`).
+			foreignKey, references := splitOption(val, "=")
+			column.ForeignKey = appendListValue(column.ForeignKey, foreignKey)
+			column.References = appendListValue(column.References, references)
+		case "embed":
+			column.Embedded = true
+			column.EmbeddedPrefix = val
+		}
+	}
+
+	column.IsNullable = !column.IsNotNull && !column.IsPrimaryKey
+
+	return column
+}
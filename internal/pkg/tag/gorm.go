@@ -0,0 +1,87 @@
+package tag
+
+import (
+	"strings"
+)
+
+// gormDialect describes the dialect of the gorm tags.
+type gormDialect struct{}
+
+// Name returns the name of the dialect.
+func (d gormDialect) Name() string {
+	return "gorm"
+}
+
+// Key returns the key of the struct tag that the dialect interprets.
+func (d gormDialect) Key() string {
+	return "gorm"
+}
+
+// IncludesUntaggedFields defines whether the exported fields without a tag are columns as well.
+func (d gormDialect) IncludesUntaggedFields() bool {
+	return true
+}
+
+// PrimaryKeyField returns the name of the field which is the primary key by convention, if any.
+func (d gormDialect) PrimaryKeyField() string {
+	return "ID"
+}
+
+// ParseColumn interprets the tag value of a field as a column.
+func (d gormDialect) ParseColumn(value string) Column {
+	return ParseGorm(value)
+}
+
+// ParseTable interprets the tag value of an embedded field and returns the name of the table, if defined.
+func (d gormDialect) ParseTable(value string) (string, bool) {
+	return "", false
+}
+
+// ParseGorm parses the value of a gorm tag (e.g. `column:user_id;primaryKey;not null`) and returns the column details.
+//
+// The keys of the gorm settings are case insensitive, the same way gorm itself handles them. As in gorm, the columns
+// are nullable unless defined as `not null` or being a primary key.
+func ParseGorm(value string) Column {
+	var column Column
+	for _, setting := range strings.Split(value, ";") {
+		key, val := splitOption(setting, ":")
+
+		switch strings.ToUpper(key) {
+		case "-":
+			column.Ignore = true
+		case "COLUMN":
+			column.Name = val
+		case "TYPE":
+			column.Type = val
+		case "SIZE":
+			column.Size = val
+		case "DEFAULT":
+			column.Default = val
+		case "PRIMARYKEY", "PRIMARY_KEY":
+			column.IsPrimaryKey = true
+		case "UNIQUE", "UNIQUEINDEX":
+			column.IsUnique = true
+		case "NOT NULL", "NOTNULL":
+			column.IsNotNull = true
+		case "FOREIGNKEY":
+			column.ForeignKey = val
+		case "REFERENCES":
+			column.References = val
+		case "MANY2MANY":
+			column.Relation = RelationManyToMany
+			column.JoinTable = val
+		case "JOINFOREIGNKEY":
+			column.JoinForeignKey = val
+		case "JOINREFERENCES":
+			column.JoinReferences = val
+		case "EMBEDDED":
+			column.Embedded = true
+		case "EMBEDDEDPREFIX":
+			column.EmbeddedPrefix = val
+		}
+	}
+
+	column.IsNullable = !column.IsNotNull && !column.IsPrimaryKey
+
+	return column
+}
@@ -0,0 +1,266 @@
+package tag
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	// RelationBelongsTo describes a relation to a model whose primary key is referred by a column of the model.
+	RelationBelongsTo = "belongs-to"
+	// RelationHasOne describes a relation to a model with a column referring to the model.
+	RelationHasOne = "has-one"
+	// RelationHasMany describes a relation to many models with a column referring to the model.
+	RelationHasMany = "has-many"
+	// RelationManyToMany describes a many to many relation between models, through a join table.
+	RelationManyToMany = "many2many"
+	// RelationKey describes the key of the struct tag declaring a relation explicitly, regardless of the dialect
+	// (e.g. `er:"fk=users.id,card=1..*"`).
+	RelationKey = "er"
+)
+
+// Column describes the details of a column as declared in the tag of a struct field.
+type Column struct {
+	Name         string
+	Type         string
+	Size         string
+	Default      string
+	IsPrimaryKey bool
+	IsUnique     bool
+	IsNotNull    bool
+	IsNullable   bool
+	Ignore       bool
+
+	// ForeignKey and References define the columns of an association, being comma separated when composite
+	// (e.g. `OrderID,LineNo`).
+	ForeignKey string
+	References string
+
+	ReferencedTable  string
+	ReferencedColumn string
+
+	// Cardinality defines the multiplicity of the referencing rows per referenced row (e.g. `1..*`).
+	Cardinality string
+
+	// Relation defines the kind of the relation (e.g. belongs-to, has-many) when the field describes a relation to
+	// another model instead of a column.
+	Relation string
+
+	// JoinTable defines the join table of a many to many relation (e.g. `user_roles`).
+	JoinTable string
+
+	// JoinForeignKey and JoinReferences define the columns of the join table of a many to many relation, referring
+	// to the model declaring the relation and to the associated model respectively.
+	JoinForeignKey string
+	JoinReferences string
+
+	// Embedded defines whether the fields of the struct need to be flattened into the columns of the table,
+	// prefixed with EmbeddedPrefix.
+	Embedded       bool
+	EmbeddedPrefix string
+}
+
+// Dialect describes the way that the tags of an ORM (or a database library) are interpreted.
+type Dialect interface {
+	// Name returns the name of the dialect, as provided in the tag option.
+	Name() string
+	// Key returns the key of the struct tag that the dialect interprets.
+	Key() string
+	// IncludesUntaggedFields defines whether the exported fields without a tag are columns as well.
+	IncludesUntaggedFields() bool
+	// PrimaryKeyField returns the name of the field which is the primary key by convention, if any.
+	PrimaryKeyField() string
+	// ParseColumn interprets the tag value of a field as a column.
+	ParseColumn(value string) Column
+	// ParseTable interprets the tag value of an embedded field and returns the name of the table, if defined.
+	ParseTable(value string) (string, bool)
+}
+
+// Registry describes the list of the supported dialects.
+type Registry struct {
+	dialects []Dialect
+
+	// explicitDialects describes the dialects that are never detected, being used only when chosen explicitly.
+	explicitDialects []Dialect
+}
+
+// NewRegistry creates and returns a registry including all the supported dialects, in the order of their priority
+// when detecting the dialect of a struct.
+//
+// The ent dialect is never detected, since its json tag is carried by any struct serialized to json (e.g. the request
+// and response structs of an api), which are not tables.
+func NewRegistry() *Registry {
+	return &Registry{
+		dialects: []Dialect{
+			gormDialect{},
+			bunDialect{},
+			xormDialect{},
+			sqlDialect{name: "sqlboiler", key: "boil"},
+			sqlDialect{name: "db", key: "db"},
+		},
+		explicitDialects: []Dialect{
+			sqlDialect{name: "ent", key: "json", primaryKeyField: "ID"},
+		},
+	}
+}
+
+// Get returns the dialect with the provided name or key. If there is no such dialect, a dialect interpreting the
+// provided tag in the `name,option,...` format is returned.
+func (r *Registry) Get(name string) Dialect {
+	dialects := append(append([]Dialect{}, r.dialects...), r.explicitDialects...)
+	for _, dialect := range dialects {
+		if dialect.Name() == name {
+			return dialect
+		}
+	}
+
+	for _, dialect := range dialects {
+		if dialect.Key() == name {
+			return dialect
+		}
+	}
+
+	return sqlDialect{name: name, key: name}
+}
+
+// Detect returns the dialect of highest priority whose tag is used in any of the provided struct tags, excluding the
+// dialects that are used only when chosen explicitly.
+func (r *Registry) Detect(structTags []string) (Dialect, bool) {
+	for _, dialect := range r.dialects {
+		for _, structTag := range structTags {
+			if _, found := reflect.StructTag(structTag).Lookup(dialect.Key()); found {
+				return dialect, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Embedding checks whether any of the supported dialects requires the fields of a struct field to be flattened
+// (e.g. `gorm:"embedded;embeddedPrefix:author_"`) and returns the prefix to apply to the flattened columns.
+func (r *Registry) Embedding(structTag string) (string, bool) {
+	for _, dialect := range r.dialects {
+		value, found := reflect.StructTag(structTag).Lookup(dialect.Key())
+		if !found {
+			continue
+		}
+
+		column := dialect.ParseColumn(value)
+		if column.Embedded {
+			return column.EmbeddedPrefix, true
+		}
+	}
+
+	return "", false
+}
+
+// sqlDialect describes the dialect of the tags following the `name,option,...` format (e.g. db, sqlboiler and ent).
+type sqlDialect struct {
+	name            string
+	key             string
+	primaryKeyField string
+}
+
+// Name returns the name of the dialect.
+func (d sqlDialect) Name() string {
+	return d.name
+}
+
+// Key returns the key of the struct tag that the dialect interprets.
+func (d sqlDialect) Key() string {
+	return d.key
+}
+
+// IncludesUntaggedFields defines whether the exported fields without a tag are columns as well.
+func (d sqlDialect) IncludesUntaggedFields() bool {
+	return false
+}
+
+// PrimaryKeyField returns the name of the field which is the primary key by convention, if any.
+func (d sqlDialect) PrimaryKeyField() string {
+	return d.primaryKeyField
+}
+
+// ParseColumn interprets the tag value of a field as a column.
+func (d sqlDialect) ParseColumn(value string) Column {
+	return Parse(value)
+}
+
+// ParseTable interprets the tag value of an embedded field and returns the name of the table, if defined.
+func (d sqlDialect) ParseTable(value string) (string, bool) {
+	return "", false
+}
+
+// Parse parses the value of a tag following the `name,option,...` format (e.g. `db:"user_id,fk=user.id"`) and
+// returns the column details. Options other than pk, fk, nullable and unique (e.g. omitempty) are ignored.
+func Parse(value string) Column {
+	parts := strings.Split(value, ",")
+
+	column := Column{Name: strings.TrimSpace(parts[0])}
+	if column.Name == "-" && len(parts) == 1 {
+		return Column{Ignore: true}
+	}
+
+	for _, option := range parts[1:] {
+		key, val := splitOption(option, "=")
+
+		switch key {
+		case "pk":
+			column.IsPrimaryKey = true
+		case "nullable":
+			column.IsNullable = true
+		case "unique":
+			column.IsUnique = true
+		case "fk":
+			column.ReferencedTable, column.ReferencedColumn = splitReference(val)
+		case "card":
+			column.Cardinality = val
+		}
+	}
+
+	return column
+}
+
+// ParseRelation parses the value of the relation tag (e.g. `er:"fk=users.id,card=1..*"`) and returns the referenced
+// table and column alongside with the cardinality of the relation.
+func ParseRelation(value string) Column {
+	var column Column
+	for _, option := range strings.Split(value, ",") {
+		key, val := splitOption(option, "=")
+
+		switch key {
+		case "fk":
+			column.ReferencedTable, column.ReferencedColumn = splitReference(val)
+		case "card":
+			column.Cardinality = val
+		}
+	}
+
+	return column
+}
+
+// splitReference splits a reference (e.g. `users.id`) to the referenced table and column.
+func splitReference(reference string) (string, string) {
+	table, column, _ := strings.Cut(reference, ".")
+	return table, column
+}
+
+// splitOption splits an option to its key and value based on the provided separator.
+func splitOption(option, separator string) (string, string) {
+	idx := strings.Index(option, separator)
+	if idx < 0 {
+		return strings.TrimSpace(option), ""
+	}
+
+	return strings.TrimSpace(option[:idx]), strings.TrimSpace(option[idx+len(separator):])
+}
+
+// appendListValue appends a value to a comma separated list of values.
+func appendListValue(list, value string) string {
+	if list == "" {
+		return value
+	}
+
+	return list + "," + value
+}
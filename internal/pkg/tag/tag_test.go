@@ -0,0 +1,451 @@
+package tag_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/eujoy/erbuilder/internal/pkg/tag"
+)
+
+func TestParse(t *testing.T) {
+	testCases := map[string]struct {
+		inputValue     string
+		expectedOutput tag.Column
+	}{
+		"Parse a tag providing only the column name": {
+			inputValue:     "user_id",
+			expectedOutput: tag.Column{Name: "user_id"},
+		},
+		"Parse a tag providing the column name alongside with options to be ignored": {
+			inputValue:     "nickname,omitempty",
+			expectedOutput: tag.Column{Name: "nickname"},
+		},
+		"Parse a tag providing the column name alongside with known options": {
+			inputValue: "code,pk,unique,nullable",
+			expectedOutput: tag.Column{
+				Name:         "code",
+				IsPrimaryKey: true,
+				IsUnique:     true,
+				IsNullable:   true,
+			},
+		},
+		"Parse a tag providing a foreign key": {
+			inputValue: "owner_id,fk=account.account_id",
+			expectedOutput: tag.Column{
+				Name:             "owner_id",
+				ReferencedTable:  "account",
+				ReferencedColumn: "account_id",
+			},
+		},
+		"Parse a tag providing a foreign key without column": {
+			inputValue: "owner_id,fk=account",
+			expectedOutput: tag.Column{
+				Name:            "owner_id",
+				ReferencedTable: "account",
+			},
+		},
+		"Parse a tag providing a foreign key with cardinality": {
+			inputValue: "owner_id,fk=account.id,card=0..1",
+			expectedOutput: tag.Column{
+				Name:             "owner_id",
+				ReferencedTable:  "account",
+				ReferencedColumn: "id",
+				Cardinality:      "0..1",
+			},
+		},
+		"Parse an ignored field": {
+			inputValue:     "-",
+			expectedOutput: tag.Column{Ignore: true},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualOutput := tag.Parse(tc.inputValue)
+			if !reflect.DeepEqual(tc.expectedOutput, actualOutput) {
+				t.Errorf("Expected to get '%v' as response but got '%v'.", tc.expectedOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestParseRelation(t *testing.T) {
+	testCases := map[string]struct {
+		inputValue     string
+		expectedOutput tag.Column
+	}{
+		"Parse a relation providing the referenced table and column": {
+			inputValue: "fk=users.id",
+			expectedOutput: tag.Column{
+				ReferencedTable:  "users",
+				ReferencedColumn: "id",
+			},
+		},
+		"Parse a relation providing the cardinality": {
+			inputValue: "fk=users.id,card=1..*",
+			expectedOutput: tag.Column{
+				ReferencedTable:  "users",
+				ReferencedColumn: "id",
+				Cardinality:      "1..*",
+			},
+		},
+		"Parse a relation providing only the cardinality": {
+			inputValue:     "card=0..1",
+			expectedOutput: tag.Column{Cardinality: "0..1"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualOutput := tag.ParseRelation(tc.inputValue)
+			if !reflect.DeepEqual(tc.expectedOutput, actualOutput) {
+				t.Errorf("Expected to get '%v' as response but got '%v'.", tc.expectedOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestParseGorm(t *testing.T) {
+	testCases := map[string]struct {
+		inputValue     string
+		expectedOutput tag.Column
+	}{
+		"Parse a tag providing the column name and constraints": {
+			inputValue: "column:user_id;primaryKey;not null;index",
+			expectedOutput: tag.Column{
+				Name:         "user_id",
+				IsPrimaryKey: true,
+				IsNotNull:    true,
+			},
+		},
+		"Parse a tag providing the type, size, default value and uniqueness": {
+			inputValue: "type:varchar(255);size:255;default:'member';uniqueIndex",
+			expectedOutput: tag.Column{
+				Type:       "varchar(255)",
+				Size:       "255",
+				Default:    "'member'",
+				IsUnique:   true,
+				IsNullable: true,
+			},
+		},
+		"Parse a tag providing the association keys": {
+			inputValue: "foreignKey:CreatorID;references:ID",
+			expectedOutput: tag.Column{
+				ForeignKey: "CreatorID",
+				References: "ID",
+				IsNullable: true,
+			},
+		},
+		"Parse a tag with settings in different case": {
+			inputValue: "COLUMN:code;PRIMARY_KEY;NOT NULL;UNIQUE",
+			expectedOutput: tag.Column{
+				Name:         "code",
+				IsPrimaryKey: true,
+				IsNotNull:    true,
+				IsUnique:     true,
+			},
+		},
+		"Parse a tag providing a many to many association": {
+			inputValue: "many2many:user_roles",
+			expectedOutput: tag.Column{
+				Relation:   tag.RelationManyToMany,
+				JoinTable:  "user_roles",
+				IsNullable: true,
+			},
+		},
+		"Parse a tag providing a many to many association with the columns of the join table": {
+			inputValue: "many2many:user_friends;joinForeignKey:UserID;joinReferences:FriendID",
+			expectedOutput: tag.Column{
+				Relation:       tag.RelationManyToMany,
+				JoinTable:      "user_friends",
+				JoinForeignKey: "UserID",
+				JoinReferences: "FriendID",
+				IsNullable:     true,
+			},
+		},
+		"Parse a tag providing a many to many association with the referenced columns": {
+			inputValue: "many2many:user_roles;foreignKey:UUID;references:UUID;joinForeignKey:UserUUID;joinReferences:RoleUUID",
+			expectedOutput: tag.Column{
+				Relation:       tag.RelationManyToMany,
+				JoinTable:      "user_roles",
+				ForeignKey:     "UUID",
+				References:     "UUID",
+				JoinForeignKey: "UserUUID",
+				JoinReferences: "RoleUUID",
+				IsNullable:     true,
+			},
+		},
+		"Parse a tag providing an embedded struct with prefix": {
+			inputValue: "embedded;embeddedPrefix:author_",
+			expectedOutput: tag.Column{
+				Embedded:       true,
+				EmbeddedPrefix: "author_",
+				IsNullable:     true,
+			},
+		},
+		"Parse an ignored field": {
+			inputValue:     "-:all",
+			expectedOutput: tag.Column{Ignore: true, IsNullable: true},
+		},
+		"Parse an empty tag": {
+			inputValue:     "",
+			expectedOutput: tag.Column{IsNullable: true},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualOutput := tag.ParseGorm(tc.inputValue)
+			if !reflect.DeepEqual(tc.expectedOutput, actualOutput) {
+				t.Errorf("Expected to get '%v' as response but got '%v'.", tc.expectedOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestParseBun(t *testing.T) {
+	testCases := map[string]struct {
+		inputValue     string
+		expectedOutput tag.Column
+	}{
+		"Parse a tag providing the column name and constraints": {
+			inputValue: "id,pk,autoincrement",
+			expectedOutput: tag.Column{
+				Name:         "id",
+				IsPrimaryKey: true,
+			},
+		},
+		"Parse a tag providing the type, default value and constraints without column name": {
+			inputValue: ",type:varchar(100),notnull,unique,default:'guest'",
+			expectedOutput: tag.Column{
+				Type:      "varchar(100)",
+				Default:   "'guest'",
+				IsNotNull: true,
+				IsUnique:  true,
+			},
+		},
+		"Parse a tag providing a relation": {
+			inputValue: "rel:belongs-to,join:author_id=id",
+			expectedOutput: tag.Column{
+				Relation:   "belongs-to",
+				ForeignKey: "author_id",
+				References: "id",
+				IsNullable: true,
+			},
+		},
+		"Parse a tag providing a relation with a composite join": {
+			inputValue: "rel:belongs-to,join:order_id=order_id,join:line_no=line_no",
+			expectedOutput: tag.Column{
+				Relation:   "belongs-to",
+				ForeignKey: "order_id,line_no",
+				References: "order_id,line_no",
+				IsNullable: true,
+			},
+		},
+		"Parse a tag providing the table of the model": {
+			inputValue:     "table:users,alias:u",
+			expectedOutput: tag.Column{Ignore: true, IsNullable: true},
+		},
+		"Parse an ignored field": {
+			inputValue:     "-",
+			expectedOutput: tag.Column{Ignore: true, IsNullable: true},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualOutput := tag.ParseBun(tc.inputValue)
+			if !reflect.DeepEqual(tc.expectedOutput, actualOutput) {
+				t.Errorf("Expected to get '%v' as response but got '%v'.", tc.expectedOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestParseXorm(t *testing.T) {
+	testCases := map[string]struct {
+		inputValue     string
+		expectedOutput tag.Column
+	}{
+		"Parse a tag providing the quoted column name and constraints": {
+			inputValue: "pk autoincr 'user_id'",
+			expectedOutput: tag.Column{
+				Name:         "user_id",
+				IsPrimaryKey: true,
+			},
+		},
+		"Parse a tag providing the type, default value and constraints": {
+			inputValue: "varchar(25) not null unique default 'guest' 'usr_name'",
+			expectedOutput: tag.Column{
+				Name:      "usr_name",
+				Type:      "varchar(25)",
+				Default:   "'guest'",
+				IsNotNull: true,
+				IsUnique:  true,
+			},
+		},
+		"Parse the tag of the xorm readme, providing a comment": {
+			inputValue: "varchar(25) notnull unique 'usr_name' comment('NickName')",
+			expectedOutput: tag.Column{
+				Name:      "usr_name",
+				Type:      "varchar(25)",
+				IsNotNull: true,
+				IsUnique:  true,
+			},
+		},
+		"Parse a tag providing a named unique index and a default value with arguments": {
+			inputValue: "unique(uq_email) varchar(64) notnull default('none') index(idx_email)",
+			expectedOutput: tag.Column{
+				Type:      "varchar(64)",
+				Default:   "'none'",
+				IsNotNull: true,
+				IsUnique:  true,
+			},
+		},
+		"Parse a tag providing a quoted column name before an unquoted one": {
+			inputValue: "'usr_name' <- nickname",
+			expectedOutput: tag.Column{
+				Name:       "usr_name",
+				IsNullable: true,
+			},
+		},
+		"Parse a tag providing an unquoted column name": {
+			inputValue: "notnull created_on",
+			expectedOutput: tag.Column{
+				Name:      "created_on",
+				IsNotNull: true,
+			},
+		},
+		"Parse a tag providing an embedded struct": {
+			inputValue:     "extends",
+			expectedOutput: tag.Column{Embedded: true, IsNullable: true},
+		},
+		"Parse an ignored field": {
+			inputValue:     "-",
+			expectedOutput: tag.Column{Ignore: true, IsNullable: true},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualOutput := tag.ParseXorm(tc.inputValue)
+			if !reflect.DeepEqual(tc.expectedOutput, actualOutput) {
+				t.Errorf("Expected to get '%v' as response but got '%v'.", tc.expectedOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestRegistryGet(t *testing.T) {
+	registry := tag.NewRegistry()
+	testCases := map[string]struct {
+		inputName   string
+		expectedKey string
+	}{
+		"Get a dialect by its name": {
+			inputName:   "sqlboiler",
+			expectedKey: "boil",
+		},
+		"Get a dialect by the key of its tag": {
+			inputName:   "boil",
+			expectedKey: "boil",
+		},
+		"Get a dialect that is never detected by its name": {
+			inputName:   "ent",
+			expectedKey: "json",
+		},
+		"Get a dialect for a tag that is not registered": {
+			inputName:   "sql",
+			expectedKey: "sql",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualDialect := registry.Get(tc.inputName)
+			if tc.expectedKey != actualDialect.Key() {
+				t.Errorf("Expected to get '%v' as tag key but got '%v'.", tc.expectedKey, actualDialect.Key())
+			}
+		})
+	}
+}
+
+func TestRegistryDetect(t *testing.T) {
+	registry := tag.NewRegistry()
+	testCases := map[string]struct {
+		inputTags     []string
+		expectedName  string
+		expectedFound bool
+	}{
+		"Detect the gorm dialect even though json tags exist on all fields": {
+			inputTags:     []string{`json:"id"`, `gorm:"not null" json:"name"`},
+			expectedName:  "gorm",
+			expectedFound: true,
+		},
+		"Detect nothing when only json tags exist": {
+			inputTags:     []string{`json:"id,omitempty"`, `json:"name,omitempty"`},
+			expectedFound: false,
+		},
+		"Detect the xorm dialect": {
+			inputTags:     []string{`xorm:"pk autoincr"`, ``},
+			expectedName:  "xorm",
+			expectedFound: true,
+		},
+		"Detect nothing when no known tag exists": {
+			inputTags:     []string{`yaml:"id"`, ``},
+			expectedFound: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualDialect, actualFound := registry.Detect(tc.inputTags)
+			if tc.expectedFound != actualFound {
+				t.Fatalf("Expected to get '%v' as found but got '%v'.", tc.expectedFound, actualFound)
+			}
+
+			if actualFound && tc.expectedName != actualDialect.Name() {
+				t.Errorf("Expected to get '%v' as dialect but got '%v'.", tc.expectedName, actualDialect.Name())
+			}
+		})
+	}
+}
+
+func TestRegistryEmbedding(t *testing.T) {
+	registry := tag.NewRegistry()
+	testCases := map[string]struct {
+		inputTag         string
+		expectedPrefix   string
+		expectedEmbedded bool
+	}{
+		"Embedded struct through gorm with prefix": {
+			inputTag:         `gorm:"embedded;embeddedPrefix:author_"`,
+			expectedPrefix:   "author_",
+			expectedEmbedded: true,
+		},
+		"Embedded struct through bun with prefix": {
+			inputTag:         `bun:"embed:billing_"`,
+			expectedPrefix:   "billing_",
+			expectedEmbedded: true,
+		},
+		"Embedded struct through xorm": {
+			inputTag:         `xorm:"extends"`,
+			expectedPrefix:   "",
+			expectedEmbedded: true,
+		},
+		"Field which is not embedded": {
+			inputTag:         `db:"name"`,
+			expectedPrefix:   "",
+			expectedEmbedded: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualPrefix, actualEmbedded := registry.Embedding(tc.inputTag)
+			if tc.expectedPrefix != actualPrefix || tc.expectedEmbedded != actualEmbedded {
+				t.Errorf("Expected to get ('%v', %v) as response but got ('%v', %v).", tc.expectedPrefix, tc.expectedEmbedded, actualPrefix, actualEmbedded)
+			}
+		})
+	}
+}
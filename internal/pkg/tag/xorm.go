@@ -0,0 +1,165 @@
+package tag
+
+import (
+	"strings"
+)
+
+// xormTypes describes the sql types that can be part of a xorm tag.
+var xormTypes = map[string]bool{
+	"bit": true, "tinyint": true, "smallint": true, "mediumint": true, "int": true, "integer": true, "bigint": true,
+	"char": true, "varchar": true, "nchar": true, "nvarchar": true, "tinytext": true, "text": true, "ntext": true,
+	"mediumtext": true, "longtext": true, "binary": true, "varbinary": true, "date": true, "datetime": true,
+	"time": true, "timestamp": true, "timestampz": true, "decimal": true, "numeric": true, "real": true,
+	"float": true, "double": true, "tinyblob": true, "blob": true, "mediumblob": true, "longblob": true,
+	"bytea": true, "bool": true, "boolean": true, "serial": true, "bigserial": true, "json": true, "jsonb": true,
+	"uuid": true,
+}
+
+// xormDialect describes the dialect of the xorm tags.
+type xormDialect struct{}
+
+// Name returns the name of the dialect.
+func (d xormDialect) Name() string {
+	return "xorm"
+}
+
+// Key returns the key of the struct tag that the dialect interprets.
+func (d xormDialect) Key() string {
+	return "xorm"
+}
+
+// IncludesUntaggedFields defines whether the exported fields without a tag are columns as well.
+func (d xormDialect) IncludesUntaggedFields() bool {
+	return true
+}
+
+// PrimaryKeyField returns the name of the field which is the primary key by convention, if any.
+func (d xormDialect) PrimaryKeyField() string {
+	return "Id"
+}
+
+// ParseColumn interprets the tag value of a field as a column.
+func (d xormDialect) ParseColumn(value string) Column {
+	return ParseXorm(value)
+}
+
+// ParseTable interprets the tag value of an embedded field and returns the name of the table, if defined.
+func (d xormDialect) ParseTable(value string) (string, bool) {
+	return "", false
+}
+
+// ParseXorm parses the value of a xorm tag (e.g. `pk autoincr 'user_id'`) and returns the column details.
+//
+// The column name is the quoted token, while any unquoted token that is not a keyword is considered to be either
+// the sql type (if known) or the column name, unless a quoted one is provided. The keywords taking arguments (e.g.
+// `comment('...')` or `unique(uq_email)`) are recognised by their prefix. As in xorm, the columns are nullable unless
+// defined as `notnull`.
+func ParseXorm(value string) Column {
+	var column Column
+	quotedName := false
+	tokens := splitXormTokens(value)
+	for idx := 0; idx < len(tokens); idx++ {
+		token := tokens[idx]
+
+		if strings.HasPrefix(token, "'") && strings.HasSuffix(token, "'") && len(token) > 1 {
+			column.Name = strings.Trim(token, "'")
+			quotedName = true
+			continue
+		}
+
+		keyword, arguments, hasArguments := getXormArguments(token)
+		if hasArguments {
+			switch keyword {
+			case "unique":
+				column.IsUnique = true
+				continue
+			case "default":
+				column.Default = arguments
+				continue
+			case "comment", "index", "extends":
+				continue
+			}
+		}
+
+		switch strings.ToLower(token) {
+		case "-":
+			column.Ignore = true
+		case "pk":
+			column.IsPrimaryKey = true
+		case "notnull":
+			column.IsNotNull = true
+		case "not":
+			if idx+1 < len(tokens) && strings.ToLower(tokens[idx+1]) == "null" {
+				column.IsNotNull = true
+				idx++
+			}
+		case "null":
+			column.IsNotNull = false
+		case "unique":
+			column.IsUnique = true
+		case "extends":
+			column.Embedded = true
+		case "default":
+			if idx+1 < len(tokens) {
+				column.Default = tokens[idx+1]
+				idx++
+			}
+		case "comment":
+			idx++
+		case "autoincr", "index", "created", "updated", "deleted", "version", "<-", "->", "cascade":
+		default:
+			sqlType := strings.ToLower(token)
+			if parenthesis := strings.Index(sqlType, "("); parenthesis >= 0 {
+				sqlType = sqlType[:parenthesis]
+			}
+
+			if xormTypes[sqlType] {
+				column.Type = token
+			} else if !quotedName && !strings.HasPrefix(token, "<-") && !strings.HasPrefix(token, "->") {
+				column.Name = token
+			}
+		}
+	}
+
+	column.IsNullable = !column.IsNotNull && !column.IsPrimaryKey
+
+	return column
+}
+
+// getXormArguments splits a token of a xorm tag taking arguments (e.g. `default('guest')`) to its keyword in lower
+// case and its arguments, if any.
+func getXormArguments(token string) (string, string, bool) {
+	parenthesis := strings.Index(token, "(")
+	if parenthesis < 0 || !strings.HasSuffix(token, ")") {
+		return "", "", false
+	}
+
+	return strings.ToLower(token[:parenthesis]), token[parenthesis+1 : len(token)-1], true
+}
+
+// splitXormTokens splits the value of a xorm tag to its space separated tokens, keeping the quoted ones intact.
+func splitXormTokens(value string) []string {
+	var tokens []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range value {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+			current.WriteRune(r)
+		case r == ' ' && !quoted:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens
+}
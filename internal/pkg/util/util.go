@@ -7,6 +7,155 @@ import (
 	"github.com/iancoleman/strcase"
 )
 
+// nullableWrappers describes the types wrapping a value that may be null (e.g. sql.NullString) and the type of the
+// wrapped value.
+var nullableWrappers = map[string]string{
+	"sql.NullBool":        "bool",
+	"sql.NullByte":        "byte",
+	"sql.NullFloat64":     "float64",
+	"sql.NullInt16":       "int16",
+	"sql.NullInt32":       "int32",
+	"sql.NullInt64":       "int64",
+	"sql.NullString":      "string",
+	"sql.NullTime":        "time.Time",
+	"null.Bool":           "bool",
+	"null.Byte":           "byte",
+	"null.Float":          "float64",
+	"null.Int":            "int64",
+	"null.Int16":          "int16",
+	"null.Int32":          "int32",
+	"null.String":         "string",
+	"null.Time":           "time.Time",
+	"nulls.Bool":          "bool",
+	"nulls.Float64":       "float64",
+	"nulls.Int":           "int",
+	"nulls.Int64":         "int64",
+	"nulls.String":        "string",
+	"nulls.Time":          "time.Time",
+	"uuid.NullUUID":       "uuid.UUID",
+	"decimal.NullDecimal": "decimal.Decimal",
+	"gorm.DeletedAt":      "time.Time",
+}
+
+// dataTypes describes the database data types of the code data types, either basic or commonly used ones.
+var dataTypes = map[string]string{
+	"bool":            "tinyint",
+	"byte":            "integer",
+	"uint":            "integer",
+	"uint8":           "integer",
+	"uint16":          "integer",
+	"uint32":          "integer",
+	"uint64":          "integer",
+	"uintptr":         "integer",
+	"rune":            "integer",
+	"int":             "integer",
+	"int8":            "integer",
+	"int16":           "integer",
+	"int32":           "integer",
+	"int64":           "integer",
+	"float32":         "float",
+	"float64":         "float",
+	"complex64":       "float",
+	"complex128":      "float",
+	"string":          "varchar",
+	"time.Time":       "datetime",
+	"time.Duration":   "interval",
+	"[]byte":          "blob",
+	"[]uint8":         "blob",
+	"json.RawMessage": "json",
+	"uuid.UUID":       "uuid",
+	"decimal.Decimal": "numeric",
+	"net.IP":          "inet",
+}
+
+// dialectDataTypes describes the database data types of the code data types per database dialect.
+var dialectDataTypes = map[string]map[string]string{
+	"postgres": {
+		"bool":            "boolean",
+		"byte":            "smallint",
+		"uint":            "bigint",
+		"uint8":           "smallint",
+		"uint16":          "integer",
+		"uint32":          "bigint",
+		"uint64":          "numeric(20)",
+		"uintptr":         "bigint",
+		"rune":            "integer",
+		"int":             "bigint",
+		"int8":            "smallint",
+		"int16":           "smallint",
+		"int32":           "integer",
+		"int64":           "bigint",
+		"float32":         "real",
+		"float64":         "double precision",
+		"string":          "text",
+		"time.Time":       "timestamptz",
+		"time.Duration":   "interval",
+		"[]byte":          "bytea",
+		"[]uint8":         "bytea",
+		"json.RawMessage": "jsonb",
+		"uuid.UUID":       "uuid",
+		"decimal.Decimal": "numeric",
+		"net.IP":          "inet",
+	},
+	"mysql": {
+		"bool":            "tinyint(1)",
+		"byte":            "tinyint unsigned",
+		"uint":            "bigint unsigned",
+		"uint8":           "tinyint unsigned",
+		"uint16":          "smallint unsigned",
+		"uint32":          "int unsigned",
+		"uint64":          "bigint unsigned",
+		"uintptr":         "bigint unsigned",
+		"rune":            "int",
+		"int":             "bigint",
+		"int8":            "tinyint",
+		"int16":           "smallint",
+		"int32":           "int",
+		"int64":           "bigint",
+		"float32":         "float",
+		"float64":         "double",
+		"string":          "varchar",
+		"time.Time":       "datetime",
+		"time.Duration":   "bigint",
+		"[]byte":          "blob",
+		"[]uint8":         "blob",
+		"json.RawMessage": "json",
+		"uuid.UUID":       "char(36)",
+		"decimal.Decimal": "decimal",
+		"net.IP":          "varbinary(16)",
+	},
+	"sqlite": {
+		"bool":            "INTEGER",
+		"byte":            "INTEGER",
+		"uint":            "INTEGER",
+		"uint8":           "INTEGER",
+		"uint16":          "INTEGER",
+		"uint32":          "INTEGER",
+		"uint64":          "INTEGER",
+		"uintptr":         "INTEGER",
+		"rune":            "INTEGER",
+		"int":             "INTEGER",
+		"int8":            "INTEGER",
+		"int16":           "INTEGER",
+		"int32":           "INTEGER",
+		"int64":           "INTEGER",
+		"float32":         "REAL",
+		"float64":         "REAL",
+		"string":          "TEXT",
+		"time.Time":       "DATETIME",
+		"time.Duration":   "INTEGER",
+		"[]byte":          "BLOB",
+		"[]uint8":         "BLOB",
+		"json.RawMessage": "TEXT",
+		"uuid.UUID":       "TEXT",
+		"decimal.Decimal": "NUMERIC",
+		"net.IP":          "TEXT",
+	},
+}
+
+// genericNullableWrappers describes the generic types wrapping a value that may be null (e.g. sql.Null[int64]).
+var genericNullableWrappers = []string{"sql.Null", "null.Value"}
+
 // Util describes the utilities package.
 type Util struct {
 	pluralize *pluralize.Client
@@ -44,24 +193,61 @@ func (u *Util) GetValueCount(toPlural bool, initialValue string) string {
 	return u.pluralize.Singular(initialValue)
 }
 
+// ResolveNullableDataType unwraps the provided data type from any pointer or nullable wrapper (e.g. `*string`,
+// `sql.NullString`, `null.String` or `sql.Null[string]`) and returns the data type of the value alongside with
+// whether it may be null. Slices and maps are nullable as well, since a nil value is stored as null.
+func (u *Util) ResolveNullableDataType(dataType string) (string, bool) {
+	dataType = strings.TrimSpace(dataType)
+
+	if strings.HasPrefix(dataType, "*") {
+		resolved, _ := u.ResolveNullableDataType(dataType[1:])
+		return resolved, true
+	}
+
+	if wrapped, found := nullableWrappers[dataType]; found {
+		return wrapped, true
+	}
+
+	for _, wrapper := range genericNullableWrappers {
+		if strings.HasPrefix(dataType, wrapper+"[") && strings.HasSuffix(dataType, "]") {
+			resolved, _ := u.ResolveNullableDataType(dataType[len(wrapper)+1 : len(dataType)-1])
+			return resolved, true
+		}
+	}
+
+	if strings.HasPrefix(dataType, "[]") || strings.HasPrefix(dataType, "map[") {
+		return dataType, true
+	}
+
+	return dataType, false
+}
+
 // GetDBDataTypeFromCodeDataType returns a database related data type based on the data type in the code provided.
 func (u *Util) GetDBDataTypeFromCodeDataType(dataType string) string {
-	if strings.Contains(dataType, "time") {
-		return "datetime"
+	dbDataType, _ := u.LookupDBDataType(dataType, "")
+	return dbDataType
+}
+
+// LookupDBDataType returns the database related data type of the provided code data type for the provided database
+// dialect (e.g. postgres) and whether it is known. Without a dialect, the default data types are used, while unknown
+// data types are described as `~`.
+func (u *Util) LookupDBDataType(dataType, dialect string) (string, bool) {
+	if strings.HasPrefix(dataType, "map[") {
+		dataType = "json.RawMessage"
 	}
 
-	switch dataType {
-	case "bool":
-		return "tinyint"
-	case "byte", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
-		return "integer"
-	case "rune", "int", "int8", "int16", "int32", "int64":
-		return "integer"
-	case "float32", "float64", "complex64", "complex128":
-		return "float"
-	case "string":
-		return "varchar"
-	default:
-		return "~"
+	mapping, found := dialectDataTypes[dialect]
+	if !found {
+		mapping = dataTypes
+	}
+
+	if dbDataType, found := mapping[dataType]; found {
+		return dbDataType, true
 	}
+
+	if dbDataType, found := dataTypes[dataType]; found {
+		return dbDataType, true
+	}
+
+	return "~", false
 }
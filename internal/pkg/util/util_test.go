@@ -158,8 +158,17 @@ func TestGetDBDataTypeFromCodeDataType(t *testing.T) {
 		"complex64":   "float",
 		"complex128":  "float",
 		"string":      "varchar",
-		"time":        "datetime",
+		"time.Time":   "datetime",
 		"random_type": "~",
+
+		"time.Duration":          "interval",
+		"Lifetime":               "~",
+		"[]byte":                 "blob",
+		"json.RawMessage":        "json",
+		"map[string]interface{}": "json",
+		"uuid.UUID":              "uuid",
+		"decimal.Decimal":        "numeric",
+		"net.IP":                 "inet",
 	}
 
 	for in, out := range dataTypes {
@@ -176,3 +185,154 @@ func TestGetDBDataTypeFromCodeDataType(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveNullableDataType(t *testing.T) {
+	testCases := map[string]struct {
+		inputValue       string
+		expectedDataType string
+		expectedNullable bool
+	}{
+		"Resolve a basic data type.": {
+			inputValue:       "string",
+			expectedDataType: "string",
+			expectedNullable: false,
+		},
+		"Resolve a pointer data type.": {
+			inputValue:       "*int64",
+			expectedDataType: "int64",
+			expectedNullable: true,
+		},
+		"Resolve a pointer to a pointer data type.": {
+			inputValue:       "**time.Time",
+			expectedDataType: "time.Time",
+			expectedNullable: true,
+		},
+		"Resolve a sql.Null* data type.": {
+			inputValue:       "sql.NullString",
+			expectedDataType: "string",
+			expectedNullable: true,
+		},
+		"Resolve a generic sql.Null data type.": {
+			inputValue:       "sql.Null[float64]",
+			expectedDataType: "float64",
+			expectedNullable: true,
+		},
+		"Resolve a null.* wrapper data type.": {
+			inputValue:       "null.Time",
+			expectedDataType: "time.Time",
+			expectedNullable: true,
+		},
+		"Resolve a gorm.DeletedAt data type.": {
+			inputValue:       "gorm.DeletedAt",
+			expectedDataType: "time.Time",
+			expectedNullable: true,
+		},
+		"Resolve a slice data type.": {
+			inputValue:       "[]string",
+			expectedDataType: "[]string",
+			expectedNullable: true,
+		},
+		"Resolve a map data type.": {
+			inputValue:       "map[string]int",
+			expectedDataType: "map[string]int",
+			expectedNullable: true,
+		},
+		"Resolve an unknown struct data type.": {
+			inputValue:       "models.Address",
+			expectedDataType: "models.Address",
+			expectedNullable: false,
+		},
+	}
+
+	utility := util.New()
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualDataType, actualNullable := utility.ResolveNullableDataType(tc.inputValue)
+			if tc.expectedDataType != actualDataType {
+				t.Errorf("Expected to get '%v' as data type but got '%v'.", tc.expectedDataType, actualDataType)
+			}
+
+			if tc.expectedNullable != actualNullable {
+				t.Errorf("Expected to get '%v' as nullable but got '%v'.", tc.expectedNullable, actualNullable)
+			}
+		})
+	}
+}
+
+func TestLookupDBDataType(t *testing.T) {
+	testCases := map[string]struct {
+		inputDataType    string
+		inputDialect     string
+		expectedDataType string
+		expectedFound    bool
+	}{
+		"Lookup a boolean without dialect.": {
+			inputDataType:    "bool",
+			inputDialect:     "",
+			expectedDataType: "tinyint",
+			expectedFound:    true,
+		},
+		"Lookup a boolean for postgres.": {
+			inputDataType:    "bool",
+			inputDialect:     "postgres",
+			expectedDataType: "boolean",
+			expectedFound:    true,
+		},
+		"Lookup a boolean for mysql.": {
+			inputDataType:    "bool",
+			inputDialect:     "mysql",
+			expectedDataType: "tinyint(1)",
+			expectedFound:    true,
+		},
+		"Lookup a boolean for sqlite.": {
+			inputDataType:    "bool",
+			inputDialect:     "sqlite",
+			expectedDataType: "INTEGER",
+			expectedFound:    true,
+		},
+		"Lookup a time for postgres.": {
+			inputDataType:    "time.Time",
+			inputDialect:     "postgres",
+			expectedDataType: "timestamptz",
+			expectedFound:    true,
+		},
+		"Lookup a string for postgres.": {
+			inputDataType:    "string",
+			inputDialect:     "postgres",
+			expectedDataType: "text",
+			expectedFound:    true,
+		},
+		"Lookup a map for postgres.": {
+			inputDataType:    "map[string]interface{}",
+			inputDialect:     "postgres",
+			expectedDataType: "jsonb",
+			expectedFound:    true,
+		},
+		"Lookup a data type missing from the dialect.": {
+			inputDataType:    "complex128",
+			inputDialect:     "sqlite",
+			expectedDataType: "float",
+			expectedFound:    true,
+		},
+		"Lookup an unknown data type.": {
+			inputDataType:    "models.Address",
+			inputDialect:     "mysql",
+			expectedDataType: "~",
+			expectedFound:    false,
+		},
+	}
+
+	utility := util.New()
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actualDataType, actualFound := utility.LookupDBDataType(tc.inputDataType, tc.inputDialect)
+			if tc.expectedDataType != actualDataType {
+				t.Errorf("Expected to get '%v' as data type but got '%v'.", tc.expectedDataType, actualDataType)
+			}
+
+			if tc.expectedFound != actualFound {
+				t.Errorf("Expected to get '%v' as found but got '%v'.", tc.expectedFound, actualFound)
+			}
+		})
+	}
+}
@@ -74,7 +74,12 @@ func (w *Writer) writeTableDetails(tableList []domain.Table) error {
 	})
 
 	for _, table := range tableList {
-		_, err := w.outputFile.WriteString(fmt.Sprintf("[%v]\n", table.Name))
+		err := w.writeDescription("", table.Description)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.outputFile.WriteString(fmt.Sprintf("[%v]\n", table.Name))
 		if err != nil {
 			return err
 		}
@@ -103,6 +108,7 @@ func (w *Writer) writeColumnsOfTable(columnList []domain.Column) error {
 	for _, column := range columnList {
 		idPrefix := ""
 		fkPrefix := ""
+		label := column.Type
 
 		if column.IsPrimaryKey {
 			idPrefix = "*"
@@ -112,13 +118,22 @@ func (w *Writer) writeColumnsOfTable(columnList []domain.Column) error {
 			fkPrefix = "+"
 		}
 
-		_, err := w.outputFile.WriteString(
+		if column.IsNullable {
+			label = fmt.Sprintf("%v NULL", label)
+		}
+
+		err := w.writeDescription("\t", column.Description)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.outputFile.WriteString(
 			fmt.Sprintf(
 				"\t%v%v%v {label: \"%v\"}\n",
 				idPrefix,
 				fkPrefix,
 				column.Name,
-				column.Type,
+				label,
 			),
 		)
 
@@ -135,6 +150,17 @@ func (w *Writer) writeColumnsOfTable(columnList []domain.Column) error {
 	return nil
 }
 
+// writeDescription writes the description of a table or a column as a comment preceding it, since the .er files
+// support no notes.
+func (w *Writer) writeDescription(indentation, description string) error {
+	if description == "" {
+		return nil
+	}
+
+	_, err := w.outputFile.WriteString(fmt.Sprintf("%v# %v\n", indentation, description))
+	return err
+}
+
 // writeForeignKeyReferences writes the foreign key references in the output file.
 func (w *Writer) writeForeignKeyReferences(referenceList []domain.Reference) error {
 	if len(referenceList) == 0 {
@@ -151,13 +177,21 @@ func (w *Writer) writeForeignKeyReferences(referenceList []domain.Reference) err
 	})
 
 	for _, foreignKey := range referenceList {
+		label := foreignKey.FromTableColumn
+		if foreignKey.ToTableColumn != "" {
+			label = fmt.Sprintf("%v -> %v", foreignKey.FromTableColumn, foreignKey.ToTableColumn)
+		}
+		if foreignKey.JunctionTable != "" {
+			label = foreignKey.JunctionTable
+		}
+
 		_, err := w.outputFile.WriteString(
 			fmt.Sprintf(
 				"%v %v %v {label: \"%v\"}\n",
 				foreignKey.FromTableName,
 				foreignKey.TypeOfReference,
 				foreignKey.ToTableName,
-				foreignKey.FromTableColumn,
+				label,
 			),
 		)
 		if err != nil {
@@ -1,6 +1,7 @@
 package writer_test
 
 import (
+	"fmt"
 	"os"
 	"reflect"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/udhos/equalfile"
 
 	"github.com/eujoy/erbuilder/internal/app/service"
+	"github.com/eujoy/erbuilder/internal/domain"
 	"github.com/eujoy/erbuilder/internal/pkg/util"
 	"github.com/eujoy/erbuilder/internal/pkg/writer"
 	test "github.com/eujoy/erbuilder/test/builder"
@@ -23,26 +25,46 @@ func TestNewWriter(t *testing.T) {
 
 func TestWriteFile(t *testing.T) {
 	dataBuilder := test.NewDataBuilder()
-	diagram := dataBuilder.GetWriterTestDiagram()
 
-	wrt := writer.New(util.New(), "./../../../test", "test-writer-example-er-diagram")
-	err := wrt.WriteFile(diagram)
-	if err != nil {
-		t.Errorf("Expected to get nil as error but got '%v'.", err)
+	testCases := map[string]struct {
+		diagram            domain.Diagram
+		filename           string
+		expectedOutputFile string
+	}{
+		"Write a diagram of tables and references": {
+			diagram:            dataBuilder.GetWriterTestDiagram(),
+			filename:           "test-writer-example-er-diagram",
+			expectedOutputFile: "./../../../test/example-er-diagram.er",
+		},
+		"Write a diagram with nullable columns, descriptions and references to specific and junction tables": {
+			diagram:            dataBuilder.GetWriterTestDiagramWithDetails(),
+			filename:           "test-writer-example-er-diagram-with-details",
+			expectedOutputFile: "./../../../test/example-er-diagram-with-writer-details.er",
+		},
 	}
 
-	cmp := equalfile.New(nil, equalfile.Options{})
-	equal, err := cmp.CompareFile("./../../../test/example-er-diagram.er", "./../../../test/test-writer-example-er-diagram.er")
-	if err != nil {
-		t.Errorf("Expected to get nil as error but got '%v'.", err)
-	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			wrt := writer.New(util.New(), "./../../../test", tc.filename)
+			err := wrt.WriteFile(tc.diagram)
+			if err != nil {
+				t.Errorf("Expected to get nil as error but got '%v'.", err)
+			}
 
-	if !equal {
-		t.Errorf("Expected that the generated file and the example test file are the same but they were not.")
-	}
+			cmp := equalfile.New(nil, equalfile.Options{})
+			equal, err := cmp.CompareFile(tc.expectedOutputFile, fmt.Sprintf("./../../../test/%v.er", tc.filename))
+			if err != nil {
+				t.Errorf("Expected to get nil as error but got '%v'.", err)
+			}
+
+			if !equal {
+				t.Errorf("Expected that the generated file and the example test file are the same but they were not.")
+			}
 
-	err = os.Remove("./../../../test/test-writer-example-er-diagram.er")
-	if err != nil {
-		t.Errorf("Expected to get nil as error when deleting the test file but got '%v'.", err)
+			err = os.Remove(fmt.Sprintf("./../../../test/%v.er", tc.filename))
+			if err != nil {
+				t.Errorf("Expected to get nil as error when deleting the test file but got '%v'.", err)
+			}
+		})
 	}
 }
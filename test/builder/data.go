@@ -56,9 +56,57 @@ func (d *DataBuilder) GetWriterTestDiagram() domain.Diagram {
 			},
 		},
 		ReferenceList: []domain.Reference{
-			createReference("phone_number", "user_id", "user", "*--*"),
-			createReference("address", "user_id", "user", "*--*"),
-			createReference("address", "city_id", "city", "*--*"),
+			createReference("phone_number", "user_id", "user", "*--1"),
+			createReference("address", "user_id", "user", "*--1"),
+			createReference("address", "city_id", "city", "*--1"),
+		},
+	}
+}
+
+// GetWriterTestDiagramWithDetails returns a diagram with nullable columns, descriptions and references to specific and
+// junction tables to be used by the writer test.
+func (d *DataBuilder) GetWriterTestDiagramWithDetails() domain.Diagram {
+	nickname := createColumn("nickname", "varchar", false, false, false)
+	nickname.IsNullable = true
+	nickname.Description = "The public name of the user."
+
+	authorID := createColumn("author_id", "integer", false, true, false)
+	authorID.IsNullable = true
+
+	userReference := createReference("post", "author_id", "user", "*--?")
+	userReference.ToTableColumn = "id"
+
+	tagReference := createReference("post", "id", "tag", "*--*")
+	tagReference.JunctionTable = "post_tag"
+
+	return domain.Diagram{
+		Title: "example_db",
+		TableList: []domain.Table{
+			{
+				Name:        "user",
+				Description: "The registered users.",
+				ColumnList: []domain.Column{
+					createColumn("id", "integer", true, false, false),
+					nickname,
+				},
+			},
+			{
+				Name: "post",
+				ColumnList: []domain.Column{
+					createColumn("id", "integer", true, false, false),
+					authorID,
+				},
+			},
+			{
+				Name: "tag",
+				ColumnList: []domain.Column{
+					createColumn("id", "integer", true, false, false),
+				},
+			},
+		},
+		ReferenceList: []domain.Reference{
+			userReference,
+			tagReference,
 		},
 	}
 }
@@ -0,0 +1,39 @@
+package models
+
+// User example test struct with has-many, has-one and many to many association fields.
+type User struct {
+	ID      uint     `gorm:"primaryKey"`
+	Name    string   `gorm:"not null"`
+	Orders  []Order  `gorm:"foreignKey:PlacedBy"`
+	Profile *Profile `gorm:"foreignKey:OwnerID"`
+	Roles   []Role   `gorm:"many2many:user_roles"`
+	Friends []User   `gorm:"many2many:user_friends"`
+}
+
+// Order example test struct belonging to the user that placed it.
+type Order struct {
+	ID        uint `gorm:"primaryKey"`
+	PlacedBy  uint
+	Total     float64
+	Courier   Courier
+	CourierID uint
+}
+
+// Courier example test struct, referred to by the orders through a belongs to association.
+type Courier struct {
+	ID   uint `gorm:"primaryKey"`
+	Name string
+}
+
+// Profile example test struct, owned by a single user.
+type Profile struct {
+	ID      uint `gorm:"primaryKey"`
+	OwnerID uint
+	Bio     *string
+}
+
+// Role example test struct, associated to many users through the user_roles join table.
+type Role struct {
+	ID   uint `gorm:"primaryKey"`
+	Name string
+}
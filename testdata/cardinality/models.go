@@ -0,0 +1,40 @@
+package models
+
+// User example test struct with association fields.
+type User struct {
+	ID        int    `db:"id,pk"`
+	Name      string `db:"name"`
+	Avatar    *Avatar
+	Addresses []Address
+	Sessions  []Session
+}
+
+// Avatar example test struct, associated to a single user.
+type Avatar struct {
+	ID     int `db:"id,pk"`
+	UserID int `db:"user_id"`
+}
+
+// Address example test struct, associated to many addresses of a user.
+type Address struct {
+	ID     int `db:"id,pk"`
+	UserID int `db:"user_id"`
+}
+
+// Passport example test struct with a unique foreign key.
+type Passport struct {
+	ID     int `db:"id,pk"`
+	UserID int `db:"user_id,unique"`
+}
+
+// Note example test struct with a nullable foreign key.
+type Note struct {
+	ID     int  `db:"id,pk"`
+	UserID *int `db:"user_id"`
+}
+
+// Session example test struct with a unique foreign key, associated to many sessions of a user.
+type Session struct {
+	ID     int `db:"id,pk"`
+	UserID int `db:"user_id,unique"`
+}
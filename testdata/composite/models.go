@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// User example test struct.
+type User struct {
+	ID   uint
+	Name string
+}
+
+// Role example test struct.
+type Role struct {
+	ID   uint
+	Name string
+}
+
+// UserRole example test struct of a junction table with a composite primary key.
+type UserRole struct {
+	UserID    uint `gorm:"primaryKey"`
+	RoleID    uint `gorm:"primaryKey"`
+	GrantedAt time.Time
+}
+
+// Order example test struct.
+type Order struct {
+	ID    uint
+	Total float64
+}
+
+// OrderLine example test struct with a composite primary key, partly referring to the order.
+type OrderLine struct {
+	OrderID  uint `gorm:"primaryKey"`
+	LineNo   int  `gorm:"primaryKey"`
+	Quantity int
+}
+
+// Shipment example test struct with a composite foreign key to the order line.
+type Shipment struct {
+	ID        uint
+	OrderID   uint
+	LineNo    int
+	OrderLine OrderLine `gorm:"foreignKey:OrderID,LineNo;references:OrderID,LineNo"`
+}
+
+// AuditEntry example test struct without any primary key.
+type AuditEntry struct {
+	Action  string
+	Payload string
+}
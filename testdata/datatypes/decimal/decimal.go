@@ -0,0 +1,9 @@
+package decimal
+
+import "math/big"
+
+// Decimal example type mirroring the commonly used decimal types.
+type Decimal struct {
+	value *big.Int
+	exp   int32
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/eujoy/erbuilder/testdata/datatypes/decimal"
+	"github.com/eujoy/erbuilder/testdata/datatypes/uuid"
+)
+
+// Money example type defined from a basic type.
+type Money int64
+
+// Invoice example test struct with commonly used data types.
+type Invoice struct {
+	ID         uuid.UUID              `db:"id,pk"`
+	ParentID   *uuid.UUID             `db:"parent_id"`
+	Lifetime   int                    `db:"lifetime"`
+	Price      decimal.Decimal        `db:"price"`
+	Balance    Money                  `db:"balance"`
+	Payload    json.RawMessage        `db:"payload"`
+	Attributes map[string]interface{} `db:"attributes"`
+	Document   []byte                 `db:"document"`
+	Timeout    time.Duration          `db:"timeout"`
+	ClientIP   net.IP                 `db:"client_ip"`
+	IssuedAt   time.Time              `db:"issued_at"`
+}
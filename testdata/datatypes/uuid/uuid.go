@@ -0,0 +1,4 @@
+package uuid
+
+// UUID example type mirroring the commonly used uuid types.
+type UUID [16]byte
@@ -0,0 +1,43 @@
+-- Schema of the shop, as dumped from the database.
+
+CREATE TABLE customer (
+    id BIGSERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    referrer_id BIGINT REFERENCES customer (id),
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS public.product (
+    sku VARCHAR(32) NOT NULL,
+    name TEXT NOT NULL,
+    price NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    CONSTRAINT product_pkey PRIMARY KEY (sku)
+);
+
+CREATE TABLE "order" (
+    id BIGSERIAL PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    placed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT order_customer_fk FOREIGN KEY (customer_id) REFERENCES customer (id) ON DELETE CASCADE
+);
+
+CREATE TABLE order_line (
+    order_id BIGINT NOT NULL REFERENCES "order",
+    line_no INTEGER NOT NULL,
+    product_sku VARCHAR(32) NOT NULL REFERENCES product (sku),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (order_id, line_no)
+);
+
+CREATE TABLE shipment (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    line_no INTEGER NOT NULL,
+    tracking_code VARCHAR(64),
+    FOREIGN KEY (order_id, line_no) REFERENCES order_line (order_id, line_no)
+);
+
+CREATE UNIQUE INDEX shipment_tracking_code_idx ON shipment (tracking_code);
+
+COMMENT ON TABLE customer IS 'The customers of the shop.';
+COMMENT ON COLUMN customer.email IS 'The email that the customer signs in with.';
@@ -0,0 +1,24 @@
+package models
+
+// Customer describes the customers placing orders.
+type Customer struct {
+	// ID identifies the customer.
+	ID int `db:"id,pk"`
+	// Email is the address the customer signs in with,
+	// unique among the customers.
+	Email string `db:"email"`
+	Name  string `db:"name"` // Name is the full name of the customer.
+}
+
+type (
+	// Order describes the orders placed by the customers.
+	Order struct {
+		ID         int     `db:"id,pk"`
+		CustomerID int     `db:"customer_id"` // CustomerID refers to the customer placing the order.
+		Total      float64 `db:"total"`
+	}
+
+	Undocumented struct {
+		ID int `db:"id,pk"`
+	}
+)
@@ -0,0 +1,38 @@
+package models
+
+import "github.com/uptrace/bun"
+
+// Customer example test struct using bun tags.
+type Customer struct {
+	bun.BaseModel `bun:"table:customers,alias:c"`
+
+	ID       int64      `bun:"id,pk,autoincrement"`
+	Email    string     `bun:"email,notnull,unique"`
+	Invoices []*Invoice `bun:"rel:has-many,join:id=customer_id"`
+}
+
+// Invoice example test struct using xorm tags.
+type Invoice struct {
+	Id         int64
+	CustomerId int64   `xorm:"notnull index 'customer_id'"`
+	Amount     float64 `xorm:"decimal(10,2) notnull"`
+	Note       string  `xorm:"-"`
+}
+
+// Payment example test struct using sqlboiler tags.
+type Payment struct {
+	ID        int       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	InvoiceID int64     `boil:"invoice_id" json:"invoice_id" toml:"invoice_id" yaml:"invoice_id"`
+	R         *paymentR `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+// paymentR example test struct of the sqlboiler relationships.
+type paymentR struct {
+	Invoice *Invoice `boil:"Invoice" json:"Invoice" toml:"Invoice" yaml:"Invoice"`
+}
+
+// RefundRequest example test struct of an api request, carrying json tags only.
+type RefundRequest struct {
+	Reason    string `json:"reason"`
+	PaymentID int    `json:"payment_id"`
+}
@@ -0,0 +1,22 @@
+CREATE TABLE "user" (
+    id BIGSERIAL PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    nickname TEXT,
+    age INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE "order" (
+    id BIGINT PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES "user" (id),
+    coupon_code TEXT NOT NULL,
+    total DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE wishlist (
+    id BIGINT PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES "user" (id),
+    updated_at TIMESTAMP WITH TIME ZONE
+);
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// User example test struct.
+type User struct {
+	ID        int64     `db:"id"`
+	Email     string    `db:"email"`
+	Nickname  *string   `db:"nickname"`
+	Age       int32     `db:"age"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Order example test struct.
+type Order struct {
+	ID         int64   `db:"id"`
+	UserID     int64   `db:"user_id"`
+	CouponCode string  `db:"coupon_code"`
+	Total      float64 `db:"total"`
+}
+
+// Wishlist example test struct, missing from the schema.
+type Wishlist struct {
+	ID     int64 `db:"id"`
+	UserID int64 `db:"user_id"`
+}
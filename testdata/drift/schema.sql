@@ -0,0 +1,20 @@
+CREATE TABLE "user" (
+    id BIGSERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    nickname TEXT,
+    age BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE coupon (
+    id BIGINT PRIMARY KEY,
+    code TEXT NOT NULL
+);
+
+CREATE TABLE "order" (
+    id BIGINT PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES "user" (id),
+    coupon_id BIGINT REFERENCES coupon (id),
+    total NUMERIC(10, 2) NOT NULL
+);
@@ -0,0 +1,10 @@
+package base
+
+import "time"
+
+// Model example base struct embedded in other structs.
+type Model struct {
+	ID        int       `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
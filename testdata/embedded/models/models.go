@@ -0,0 +1,29 @@
+package models
+
+import "github.com/eujoy/erbuilder/testdata/embedded/base"
+
+// Author example struct flattened through the gorm embedded option.
+type Author struct {
+	Name  string `db:"name"`
+	Email string `db:"email"`
+}
+
+// Audit example struct embedded by pointer.
+type Audit struct {
+	CreatedBy int `db:"created_by"`
+}
+
+// Post example test struct.
+type Post struct {
+	base.Model
+	*Audit
+	Title  string `db:"title"`
+	Author Author `gorm:"embedded;embeddedPrefix:author_"`
+}
+
+// Comment example test struct.
+type Comment struct {
+	base.Model
+	PostID int    `db:"post_id"`
+	Body   string `db:"body"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Timestamps example test struct, embedded into the other structs without being a model.
+type Timestamps struct {
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Base example test struct, embedded into the other structs without being a model, even though it declares the
+// primary key.
+type Base struct {
+	ID int `db:"id,pk"`
+	Timestamps
+}
+
+// User example test struct, embedded into AdminUser while being a model itself.
+type User struct {
+	Base
+	Email string `db:"email"`
+}
+
+// AdminUser example test struct.
+type AdminUser struct {
+	User
+	Role string `db:"role"`
+}
+
+// Session example test struct, referring to the user.
+type Session struct {
+	Base
+	UserID int    `db:"user_id"`
+	Token  string `db:"token"`
+	User   *User
+}
@@ -0,0 +1,26 @@
+package models
+
+// Payment example test struct generated by ent.
+type Payment struct {
+	ID     int          `json:"id,omitempty"`
+	Amount float64      `json:"amount,omitempty"`
+	Edges  PaymentEdges `json:"edges"`
+}
+
+// PaymentEdges example test struct of the ent edges.
+type PaymentEdges struct {
+	Refunds []*Refund `json:"refunds,omitempty"`
+}
+
+// Refund example test struct generated by ent.
+type Refund struct {
+	ID        int         `json:"id,omitempty"`
+	Reason    string      `json:"reason,omitempty"`
+	PaymentID int         `json:"payment_id,omitempty"`
+	Edges     RefundEdges `json:"edges"`
+}
+
+// RefundEdges example test struct of the ent edges.
+type RefundEdges struct {
+	Payment *Payment `json:"payment,omitempty"`
+}
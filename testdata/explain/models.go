@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Status example test type, which is not a struct.
+type Status string
+
+// Timestamps example test struct, embedded into the other structs.
+type Timestamps struct {
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Page example test struct, which is generic.
+type Page[T any] struct {
+	Items []T `db:"items"`
+}
+
+// Options example test struct without any tags.
+type Options struct {
+	Verbose bool
+}
+
+// Author example test struct.
+type Author struct {
+	ID    int    `db:"id,pk"`
+	Name  string `db:"name"`
+	Books []Book
+	Timestamps
+}
+
+// Book example test struct.
+type Book struct {
+	ID       int     `db:"id,pk"`
+	AuthorID int     `db:"author_id"`
+	Status   Status  `db:"status"`
+	Price    float64 `db:"price"`
+	Cover    []byte  `db:"cover"`
+	Draft    string  `db:"-"`
+	secret   string
+}
@@ -0,0 +1,22 @@
+package models
+
+import "gorm.io/gorm"
+
+// User example test struct using gorm tags.
+type User struct {
+	gorm.Model
+	Name     string `gorm:"size:100;not null"`
+	Email    string `gorm:"column:email_address;type:varchar(255);unique;not null"`
+	Role     string `gorm:"default:member"`
+	Password string `gorm:"-"`
+	internal string
+	Orders   []Order
+}
+
+// Order example test struct using gorm tags.
+type Order struct {
+	OrderID uint    `gorm:"column:order_id;primaryKey"`
+	BuyerID uint    `gorm:"not null;index"`
+	Buyer   User    `gorm:"foreignKey:BuyerID;references:ID"`
+	Total   float64 `gorm:"NOT NULL"`
+}
@@ -0,0 +1,9 @@
+package models
+
+import "gorm.io/gorm"
+
+// Post example test struct embedding the gorm model, mapped through db tags.
+type Post struct {
+	gorm.Model
+	Title string `db:"title"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+type (
+	// User example test struct declared in a grouped type declaration.
+	User struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+
+	// Order example test struct with anonymous nested structs.
+	Order struct {
+		ID       int `db:"id"`
+		UserID   int `db:"user_id"`
+		Shipping struct {
+			Street string `db:"shipping_street"`
+			City   string `db:"shipping_city"`
+		}
+		Meta struct {
+			Note string
+		} `db:"meta"`
+	}
+
+	// Status example test type which is not a struct.
+	Status string
+)
+
+// Entity example generic test struct, used only through its instantiations.
+type Entity[K comparable] struct {
+	ID        K         `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Product example test struct embedding an instantiated generic struct.
+type Product struct {
+	Entity[int64]
+	Title string `db:"title"`
+}
+
+// Page example generic test struct which is not a table.
+type Page[T any] struct {
+	Items []T `db:"items"`
+	Total int `db:"total"`
+}
@@ -0,0 +1,30 @@
+package models
+
+// User example test struct.
+type User struct {
+	ID       int    `db:"id"`
+	Username string `db:"username"`
+}
+
+// Role example test struct.
+type Role struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+// UserRole example test struct, whose name contains the names of other tables.
+type UserRole struct {
+	ID     int `db:"id"`
+	UserID int `db:"user_id"`
+	RoleID int `db:"role_id"`
+}
+
+// Session example test struct with columns containing table names without referring to them.
+type Session struct {
+	ID            int    `db:"id"`
+	UserID        int    `db:"user_id"`
+	UserRoleID    int    `db:"user_role_id"`
+	UserAgent     string `db:"user_agent"`
+	SuperuserFlag bool   `db:"superuser_flag"`
+	Owner         int    `db:"owner"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Student example test struct.
+type Student struct {
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+// Course example test struct.
+type Course struct {
+	ID    int    `db:"id,pk"`
+	Title string `db:"title"`
+}
+
+// Tag example test struct.
+type Tag struct {
+	ID    int    `db:"id,pk"`
+	Label string `db:"label"`
+}
+
+// StudentCourse example test struct of a junction table with a timestamp.
+type StudentCourse struct {
+	StudentID  int       `db:"student_id,pk"`
+	CourseID   int       `db:"course_id,pk"`
+	EnrolledAt time.Time `db:"enrolled_at"`
+}
+
+// CourseTag example test struct of a junction table with the foreign keys only.
+type CourseTag struct {
+	CourseID int `db:"course_id,pk"`
+	TagID    int `db:"tag_id,pk"`
+}
+
+// Grade example test struct referring to two tables, without being a junction table.
+type Grade struct {
+	StudentID int     `db:"student_id,pk"`
+	CourseID  int     `db:"course_id,pk"`
+	Score     float64 `db:"score"`
+}
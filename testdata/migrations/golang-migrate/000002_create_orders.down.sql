@@ -0,0 +1,2 @@
+DROP TABLE legacy_audit;
+DROP TABLE orders;
@@ -0,0 +1,11 @@
+CREATE TABLE orders (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users (id),
+    total NUMERIC(10, 2)
+);
+
+CREATE TABLE legacy_audit (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users (id),
+    payload TEXT
+);
@@ -0,0 +1,5 @@
+ALTER TABLE orders DROP COLUMN amount_cents;
+ALTER TABLE orders ADD COLUMN total NUMERIC(10, 2);
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+ALTER TABLE users DROP COLUMN created_at;
+ALTER TABLE users RENAME COLUMN full_name TO name;
@@ -0,0 +1,10 @@
+ALTER TABLE users RENAME COLUMN name TO full_name;
+ALTER TABLE users
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT now(),
+    ALTER COLUMN email SET NOT NULL;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE orders DROP COLUMN total;
+ALTER TABLE orders ADD COLUMN amount_cents BIGINT NOT NULL DEFAULT 0;
+
+DROP TABLE IF EXISTS legacy_audit;
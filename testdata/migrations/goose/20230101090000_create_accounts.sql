@@ -0,0 +1,8 @@
+-- +goose Up
+CREATE TABLE accounts (
+    id BIGINT PRIMARY KEY,
+    handle TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE accounts;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE sessions (
+    token TEXT PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts (id)
+);
+-- +goose StatementEnd
+ALTER TABLE accounts RENAME COLUMN handle TO username;
+
+-- +goose Down
+ALTER TABLE accounts RENAME COLUMN username TO handle;
+DROP TABLE sessions;
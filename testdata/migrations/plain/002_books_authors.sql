@@ -0,0 +1 @@
+ALTER TABLE books ADD COLUMN author_id INTEGER REFERENCES authors (id);
@@ -0,0 +1,2 @@
+-- not a migration, since its name does not start with a version.
+DROP TABLE books;
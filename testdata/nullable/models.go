@@ -0,0 +1,20 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Customer example test struct with nullable fields.
+type Customer struct {
+	ID          int               `db:"id,pk"`
+	Name        string            `db:"name"`
+	Nickname    *string           `db:"nickname"`
+	Email       sql.NullString    `db:"email"`
+	Age         sql.NullInt32     `db:"age"`
+	Score       sql.Null[float64] `db:"score"`
+	ConfirmedAt sql.NullTime      `db:"confirmed_at"`
+	DeletedAt   *time.Time        `db:"deleted_at"`
+	Tags        []string          `db:"tags"`
+	Settings    map[string]string `db:"settings"`
+}
@@ -0,0 +1,15 @@
+package models
+
+// Customer example test struct of a legacy table.
+type Customer struct {
+	CustNo int    `db:"cust_no,pk"`
+	Name   string `db:"name"`
+}
+
+// Invoice example test struct of a legacy table, declaring its relations explicitly.
+type Invoice struct {
+	InvNo int    `db:"inv_no,pk"`
+	Buyer int    `db:"buyer" er:"fk=customer.cust_no,card=1..*"`
+	Payer *int   `db:"payer" er:"fk=customer.cust_no,card=0..*"`
+	Notes string `db:"notes"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// Base example test struct, sharing its name and the name of its package with the one of catalog.
+type Base struct {
+	ID int `db:"id"`
+}
+
+// User example test struct.
+type User struct {
+	Base
+	Email string `db:"email"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// Base example test struct, sharing its name and the name of its package with the one of accounts.
+type Base struct {
+	Code string `db:"code"`
+}
+
+// Product example test struct.
+type Product struct {
+	Base
+	Name string `db:"name"`
+}
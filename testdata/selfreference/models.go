@@ -0,0 +1,31 @@
+package models
+
+// Employee example test struct referring to its manager, being an employee as well.
+type Employee struct {
+	ID        int    `db:"id,pk"`
+	Name      string `db:"name"`
+	ManagerID *int   `db:"manager_id"`
+	Manager   *Employee
+	Reports   []Employee
+}
+
+// Category example test struct referring to its parent category.
+type Category struct {
+	ID               int    `db:"id,pk"`
+	Title            string `db:"title"`
+	ParentCategoryID *int   `db:"parent_category_id"`
+}
+
+// User example test struct.
+type User struct {
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+// Message example test struct referring to the user table twice.
+type Message struct {
+	ID              int    `db:"id,pk"`
+	Body            string `db:"body"`
+	SenderUserID    int    `db:"sender_user_id"`
+	RecipientUserID int    `db:"recipient_user_id"`
+}
@@ -0,0 +1,29 @@
+-- Schema of the sqlite database that the introspection is tested against, created on each run.
+
+CREATE TABLE device (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    serial_number TEXT NOT NULL UNIQUE,
+    name VARCHAR(64),
+    registered_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE sensor (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    device_id INTEGER NOT NULL REFERENCES device (id) ON DELETE CASCADE,
+    kind TEXT NOT NULL
+);
+
+CREATE TABLE reading (
+    sensor_id INTEGER NOT NULL REFERENCES sensor,
+    taken_at DATETIME NOT NULL,
+    value REAL NOT NULL,
+    PRIMARY KEY (sensor_id, taken_at)
+);
+
+CREATE TABLE device_config (
+    device_id INTEGER NOT NULL,
+    payload BLOB,
+    FOREIGN KEY (device_id) REFERENCES device (id)
+);
+
+CREATE UNIQUE INDEX device_config_device_idx ON device_config (device_id);
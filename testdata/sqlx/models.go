@@ -0,0 +1,16 @@
+package models
+
+// Account example test struct using db tag options.
+type Account struct {
+	AccountID int     `db:"account_id,pk"`
+	Username  string  `db:"username,unique"`
+	Nickname  *string `db:"nickname,omitempty,nullable"`
+	Secret    string  `db:"-"`
+}
+
+// Session example test struct using db tag options.
+type Session struct {
+	ID        int    `db:"id"`
+	OwnerID   int    `db:"owner_id,fk=account.account_id"`
+	UserAgent string `db:"user_agent,omitempty"`
+}
@@ -0,0 +1,36 @@
+package models
+
+const accountsTable = "app_accounts"
+
+// User example test struct overriding its table name.
+type User struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+// TableName returns the name of the table for the user.
+func (User) TableName() string {
+	return "app_users"
+}
+
+// Order example test struct overriding its table name through a pointer receiver.
+type Order struct {
+	ID        int `db:"id"`
+	AppUserID int `db:"app_user_id"`
+}
+
+// TableName returns the name of the table for the order.
+func (o *Order) TableName() string {
+	return "app_orders"
+}
+
+// Account example test struct overriding its table name with a constant.
+type Account struct {
+	ID        int `db:"id"`
+	AppUserID int `db:"app_user_id"`
+}
+
+// TableName returns the name of the table for the account.
+func (Account) TableName() string {
+	return accountsTable
+}
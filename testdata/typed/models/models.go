@@ -0,0 +1,16 @@
+package models
+
+import "github.com/eujoy/erbuilder/testdata/typed/mytime"
+
+// Status example type defined from a basic type.
+type Status string
+
+// Event example test struct.
+type Event struct {
+	ID        int            `db:"id"`
+	Name      string         `db:"name"`
+	Status    Status         `db:"status"`
+	CreatedAt mytime.Stamp   `db:"created_at"`
+	UpdatedAt mytime.Moment  `db:"updated_at"`
+	Duration  mytime.Seconds `db:"duration"`
+}
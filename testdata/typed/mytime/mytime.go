@@ -0,0 +1,12 @@
+package mytime
+
+import "time"
+
+// Stamp example type defined from another package's type.
+type Stamp time.Time
+
+// Moment example alias of another package's type.
+type Moment = time.Time
+
+// Seconds example type defined from a basic type.
+type Seconds int64